@@ -0,0 +1,118 @@
+package jester
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Append appends vals to the array at the specified path. A missing or null
+// value at the path is replaced by a new array.
+func (d *Data) Append(branch []any, vals ...any) error {
+	return d.updateArray("append", branch, true, func(s []any) ([]any, error) {
+		return append(s, vals...), nil
+	})
+}
+
+// Insert inserts val into the array at the specified path before index,
+// shifting the following elements up. An index equal to the length appends.
+func (d *Data) Insert(branch []any, index int, val any) error {
+	return d.updateArray("insert", branch, false, func(s []any) ([]any, error) {
+		if err := checkPosition(index, len(s)); err != nil {
+			return nil, err
+		}
+		return slices.Insert(s, index, val), nil
+	})
+}
+
+// RemoveAt removes the element at index from the array at the specified path,
+// shifting the following elements down.
+func (d *Data) RemoveAt(branch []any, index int) error {
+	return d.updateArray("remove", branch, false, func(s []any) ([]any, error) {
+		if err := checkIndex(index, len(s)); err != nil {
+			return nil, err
+		}
+		return slices.Delete(s, index, index+1), nil
+	})
+}
+
+// Splice removes up to deleteCount elements starting at start from the array
+// at the specified path and inserts vals in their place, like JavaScript's
+// Array.prototype.splice.
+func (d *Data) Splice(branch []any, start, deleteCount int, vals ...any) error {
+	return d.updateArray("splice", branch, false, func(s []any) ([]any, error) {
+		if err := checkPosition(start, len(s)); err != nil {
+			return nil, err
+		}
+		if deleteCount < 0 {
+			return nil, fmt.Errorf("%w: negative delete count %d", ErrNegativeIndex, deleteCount)
+		}
+		end := min(start+deleteCount, len(s))
+		return slices.Replace(s, start, end, vals...), nil
+	})
+}
+
+// Swap exchanges the elements at indexes i and j of the array at the specified path.
+func (d *Data) Swap(branch []any, i, j int) error {
+	return d.updateArray("swap", branch, false, func(s []any) ([]any, error) {
+		if err := checkIndex(i, len(s)); err != nil {
+			return nil, err
+		}
+		if err := checkIndex(j, len(s)); err != nil {
+			return nil, err
+		}
+		s[i], s[j] = s[j], s[i]
+		return s, nil
+	})
+}
+
+// updateArray replaces the array at the specified path with the result of fn.
+// When create is set, a missing or null value is treated as an empty array.
+func (d *Data) updateArray(op string, branch []any, create bool, fn func([]any) ([]any, error)) error {
+	path, err := opPath(op, branch)
+	if err != nil {
+		return err
+	}
+
+	root, err := modifyPath(d.data, path, 0, op, func(v any, exists bool) (any, error) {
+		s, ok := v.([]any)
+		switch {
+		case ok:
+		case v == nil && create:
+			s = []any{}
+		case !exists:
+			return nil, ErrNotFound
+		default:
+			return nil, fmt.Errorf("%w: %s is not an array", ErrTypeMismatch, New(v).Kind())
+		}
+		return fn(s)
+	})
+	if err != nil {
+		return err
+	}
+
+	d.data, d.missing = root, false
+	return nil
+}
+
+// checkIndex reports whether index is within [0, n).
+func checkIndex(index, n int) error {
+	if index < 0 {
+		return ErrNegativeIndex
+	}
+	if index >= n {
+		return fmt.Errorf("%w: index %d with length %d", ErrIndexOutOfRange, index, n)
+	}
+	return nil
+}
+
+// checkPosition reports whether index is within [0, n], the positions at
+// which an element can be inserted into an array of length n.
+func checkPosition(index, n int) error {
+	if index < 0 {
+		return ErrNegativeIndex
+	}
+	if index > n {
+		return fmt.Errorf("%w: index %d with length %d", ErrIndexOutOfRange, index, n)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package jester_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestArrayOperations(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"guild": {"members": ["a", "b", "c"]}}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	members := []any{"guild", "members"}
+
+	if err := js.Append(members, "d", "e"); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js.Get(members...), `["a", "b", "c", "d", "e"]`)
+
+	if err := js.Insert(members, 0, "z"); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if err := js.Insert(members, 6, "end"); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js.Get(members...), `["z", "a", "b", "c", "d", "e", "end"]`)
+
+	if err := js.RemoveAt(members, 2); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js.Get(members...), `["z", "a", "c", "d", "e", "end"]`)
+
+	if err := js.Splice(members, 1, 3, "x", "y"); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js.Get(members...), `["z", "x", "y", "e", "end"]`)
+
+	if err := js.Splice(members, 3, 10); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js.Get(members...), `["z", "x", "y"]`)
+
+	if err := js.Swap(members, 0, 2); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js.Get(members...), `["y", "x", "z"]`)
+
+	if err := js.Append([]any{"guild", "roles"}, 1); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js.Get("guild", "roles"), `[1]`)
+
+	root := jester.New([]any{1})
+	if err := root.Append(nil, 2); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, root, `[1, 2]`)
+}
+
+func TestArrayOperationErrors(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"list": [1, 2], "s": "x", "nested": [{"list": []}]}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	cases := []struct {
+		name string
+		err  error
+		run  func() error
+	}{
+		{"insert past end", jester.ErrIndexOutOfRange, func() error { return js.Insert([]any{"list"}, 3, 0) }},
+		{"insert negative", jester.ErrNegativeIndex, func() error { return js.Insert([]any{"list"}, -1, 0) }},
+		{"remove past end", jester.ErrIndexOutOfRange, func() error { return js.RemoveAt([]any{"list"}, 2) }},
+		{"remove missing", jester.ErrNotFound, func() error { return js.RemoveAt([]any{"missing"}, 0) }},
+		{"remove deep missing", jester.ErrNotFound, func() error { return js.RemoveAt([]any{"missing", "list"}, 0) }},
+		{"append to string", jester.ErrTypeMismatch, func() error { return js.Append([]any{"s"}, 0) }},
+		{"swap out of range", jester.ErrIndexOutOfRange, func() error { return js.Swap([]any{"list"}, 0, 5) }},
+		{"splice negative", jester.ErrNegativeIndex, func() error { return js.Splice([]any{"list"}, 0, -1) }},
+		{"nested out of range", jester.ErrIndexOutOfRange, func() error { return js.Append([]any{"nested", 1, "list"}, 0) }},
+		{"key on array", jester.ErrTypeMismatch, func() error { return js.Append([]any{"nested", "x"}, 0) }},
+	}
+
+	for _, tc := range cases {
+		err := tc.run()
+		var pe *jester.PathError
+		if !errors.As(err, &pe) || !errors.Is(err, tc.err) {
+			t.Errorf("%s: got %v expected %v", tc.name, err, tc.err)
+		}
+	}
+
+	assertJSON(t, js, `{"list": [1, 2], "s": "x", "nested": [{"list": []}]}`)
+
+	if err := js.Insert([]any{"list"}, 5, 0); err == nil || !strings.Contains(err.Error(), "index 5 with length 2") {
+		t.Errorf("got %v", err)
+	}
+
+	if err := js.Append([]any{"nested", 0, "list"}, "v"); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js.Get("nested"), `[{"list": ["v"]}]`)
+}
@@ -0,0 +1,212 @@
+package jester
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/goccy/go-json"
+)
+
+var dataType = reflect.TypeFor[*Data]()
+
+// As converts the data to T. Supported targets are bool, string, all integer
+// and float types, slices, arrays and maps with string keys of supported
+// types, pointers, any and *Data; structs and other types are decoded from
+// the JSON encoding of the data. Numbers are converted exactly: values that
+// are fractional or out of range for an integer type fail with ErrNotInteger
+// or ErrOutOfRange instead of being truncated. A null converts to the zero
+// value of T, as StringSlice converts null elements to "". Errors for nested
+// values report their location.
+func As[T any](d *Data) (T, error) {
+	var out T
+	if d.missing {
+		return out, ErrNotFound
+	}
+	err := convertValue(reflect.ValueOf(&out).Elem(), d.data, nil, d.lenient)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return out, nil
+}
+
+// MustAs converts the data to T like As, returning def if it cannot be converted.
+func MustAs[T any](d *Data, def T) T {
+	if v, err := As[T](d); err == nil {
+		return v
+	}
+	return def
+}
+
+// GetAs retrieves the value at the specified path like Get and converts it to T like As.
+func GetAs[T any](d *Data, keys ...any) (T, error) {
+	data, ok := d.Lookup(keys...)
+	if !ok {
+		var zero T
+		if p, err := NewPath(keys...); err == nil {
+			return zero, fmt.Errorf("%w (at %q)", ErrNotFound, p.Pointer())
+		}
+		return zero, ErrNotFound
+	}
+	return As[T](data)
+}
+
+// convertValue stores v in dst, which must be settable. path holds the
+// pointer tokens of v for error messages, and lenient converts numeric
+// strings to numbers as for a lenient Data.
+func convertValue(dst reflect.Value, v any, path []string, lenient bool) error {
+	t := dst.Type()
+	if t == dataType {
+		dst.Set(reflect.ValueOf(&Data{data: v, lenient: lenient}))
+		return nil
+	}
+	if v == nil {
+		dst.SetZero()
+		return nil
+	}
+	if t.Kind() == reflect.Interface && t.NumMethod() == 0 {
+		dst.Set(reflect.ValueOf(v))
+		return nil
+	}
+
+	fail := func(err error) error {
+		if len(path) == 0 {
+			return err
+		}
+		return pointerError(err, path)
+	}
+	mismatch := func() error {
+		return fail(fmt.Errorf("%w: cannot convert %s to %s", ErrTypeMismatch, New(v).Kind(), t))
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		if lenient {
+			v = lenientNumber(v)
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		b, ok := v.(bool)
+		if !ok {
+			return mismatch()
+		}
+		dst.SetBool(b)
+	case reflect.String:
+		if n, ok := v.(json.Number); ok && t == reflect.TypeFor[json.Number]() {
+			dst.SetString(string(n))
+			return nil
+		}
+		s, ok := v.(string)
+		if !ok {
+			return mismatch()
+		}
+		dst.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !isNumber(v) {
+			return mismatch()
+		}
+		i, err := intValue(v, t.Bits())
+		if err != nil {
+			return fail(err)
+		}
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if !isNumber(v) {
+			return mismatch()
+		}
+		u, err := uintValue(v, t.Bits())
+		if err != nil {
+			return fail(err)
+		}
+		dst.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		if !isNumber(v) {
+			return mismatch()
+		}
+		f, err := floatValue(v, t.Bits())
+		if err != nil {
+			return fail(err)
+		}
+		dst.SetFloat(f)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are encoded as base64 strings in JSON.
+			switch b := v.(type) {
+			case []byte:
+				dst.SetBytes(append([]byte(nil), b...))
+				return nil
+			case string:
+				raw, err := base64.StdEncoding.DecodeString(b)
+				if err != nil {
+					return fail(fmt.Errorf("%w: %w", ErrTypeMismatch, err))
+				}
+				dst.SetBytes(raw)
+				return nil
+			}
+		}
+		s, ok := v.([]any)
+		if !ok {
+			return mismatch()
+		}
+		out := reflect.MakeSlice(t, len(s), len(s))
+		for i, e := range s {
+			if err := convertValue(out.Index(i), e, appendToken(path, strconv.Itoa(i)), lenient); err != nil {
+				return err
+			}
+		}
+		dst.Set(out)
+	case reflect.Array:
+		s, ok := v.([]any)
+		if !ok {
+			return mismatch()
+		}
+		if len(s) != t.Len() {
+			return fail(fmt.Errorf("%w: cannot convert array of length %d to %s", ErrTypeMismatch, len(s), t))
+		}
+		for i, e := range s {
+			if err := convertValue(dst.Index(i), e, appendToken(path, strconv.Itoa(i)), lenient); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		m, ok := v.(map[string]any)
+		if !ok || t.Key().Kind() != reflect.String {
+			return mismatch()
+		}
+		out := reflect.MakeMapWithSize(t, len(m))
+		for k, e := range m {
+			elem := reflect.New(t.Elem()).Elem()
+			if err := convertValue(elem, e, appendToken(path, k), lenient); err != nil {
+				return err
+			}
+			out.SetMapIndex(reflect.ValueOf(k).Convert(t.Key()), elem)
+		}
+		dst.Set(out)
+	case reflect.Pointer:
+		elem := reflect.New(t.Elem())
+		if err := convertValue(elem.Elem(), v, path, lenient); err != nil {
+			return err
+		}
+		dst.Set(elem)
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return fail(fmt.Errorf("%w: unsupported target type %s", ErrTypeMismatch, t))
+	default:
+		// Structs and anything else go through the JSON encoding of the value.
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return fail(err)
+		}
+		ptr := reflect.New(t)
+		if err := json.Unmarshal(raw, ptr.Interface()); err != nil {
+			return fail(fmt.Errorf("%w: %w", ErrTypeMismatch, err))
+		}
+		dst.Set(ptr.Elem())
+	}
+	return nil
+}
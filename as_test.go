@@ -0,0 +1,112 @@
+package jester_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestAs(t *testing.T) {
+	d, err := jester.NewJson([]byte(`{"id": 12, "big": 300, "ratio": 0.5, "exp": 1e3, "name": "g", "ok": true,
+		"ids": [1, 2, null], "perms": {"read": "yes", "write": "no"}, "owner": {"id": 7, "name": "o"}, "none": null,
+		"raw": "aGk="}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	if v, err := jester.GetAs[int32](d, "id"); err != nil || v != 12 {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := jester.GetAs[uint](d, "exp"); err != nil || v != 1000 {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := jester.GetAs[float32](d, "ratio"); err != nil || v != 0.5 {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := jester.GetAs[string](d, "name"); err != nil || v != "g" {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := jester.GetAs[bool](d, "ok"); err != nil || !v {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := jester.GetAs[[]int](d, "ids"); err != nil || !reflect.DeepEqual(v, []int{1, 2, 0}) {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := jester.GetAs[map[string]string](d, "perms"); err != nil || !reflect.DeepEqual(v, map[string]string{"read": "yes", "write": "no"}) {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := jester.GetAs[[]byte](d, "raw"); err != nil || string(v) != "hi" {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := jester.GetAs[json.Number](d, "id"); err != nil || v != "12" {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := jester.GetAs[*int](d, "none"); err != nil || v != nil {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := jester.GetAs[*int](d, "id"); err != nil || v == nil || *v != 12 {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := jester.GetAs[*jester.Data](d, "owner"); err != nil || v.Get("id").MustInt() != 7 {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	if v, err := jester.GetAs[user](d, "owner"); err != nil || v != (user{7, "o"}) {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := jester.GetAs[map[string]user](d, "none"); err != nil || v != nil {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+}
+
+func TestAsErrors(t *testing.T) {
+	d, err := jester.NewJson([]byte(`{"big": 300, "neg": -1, "ratio": 0.5, "name": "g", "ids": [1, "x"]}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	if _, err := jester.GetAs[int8](d, "big"); !errors.Is(err, jester.ErrOutOfRange) {
+		t.Errorf("err %#v", err)
+	}
+	if _, err := jester.GetAs[uint64](d, "neg"); !errors.Is(err, jester.ErrOutOfRange) {
+		t.Errorf("err %#v", err)
+	}
+	if _, err := jester.GetAs[int](d, "ratio"); !errors.Is(err, jester.ErrNotInteger) {
+		t.Errorf("err %#v", err)
+	}
+	if _, err := jester.As[float64](jester.New(json.Number("1e400"))); !errors.Is(err, jester.ErrOutOfRange) {
+		t.Errorf("err %#v", err)
+	}
+	if _, err := jester.GetAs[int](d, "name"); !errors.Is(err, jester.ErrTypeMismatch) {
+		t.Errorf("err %#v", err)
+	}
+	if _, err := jester.GetAs[int](d, "missing"); !errors.Is(err, jester.ErrNotFound) {
+		t.Errorf("err %#v", err)
+	}
+
+	_, err = jester.GetAs[[]int](d, "ids")
+	if !errors.Is(err, jester.ErrTypeMismatch) {
+		t.Fatalf("err %#v", err)
+	}
+	if expected := `jester: type assertion failed (type mismatch): cannot convert string to int (at "/1")`; err.Error() != expected {
+		t.Errorf("got %q expected %q", err.Error(), expected)
+	}
+}
+
+func TestMustAs(t *testing.T) {
+	d := jester.New(map[string]any{"id": "12"})
+
+	if v := jester.MustAs(d.Get("id"), 5); v != 5 {
+		t.Errorf("got %#v", v)
+	}
+	if v := jester.MustAs(d.Get("id"), ""); v != "12" {
+		t.Errorf("got %#v", v)
+	}
+}
@@ -0,0 +1,106 @@
+package jester
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/goccy/go-json"
+)
+
+var ErrInexact = errors.New("jester: number has no exact JSON representation")
+
+// BigInt returns the underlying number as a *big.Int without losing precision.
+// Integral values in any form are accepted, such as json.Number("1e30");
+// fractional values fail with ErrNotInteger.
+func (d *Data) BigInt() (*big.Int, error) {
+	r, err := d.Rat()
+	if err != nil {
+		return nil, err
+	}
+	if !r.IsInt() {
+		return nil, fmt.Errorf("%w: %s", ErrNotInteger, r.FloatString(10))
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// BigFloat returns the underlying number as a *big.Float. A json.Number is
+// parsed with enough precision to hold all of its decimal digits, so large
+// amounts are not rounded to the 53 bits of a float64.
+func (d *Data) BigFloat() (*big.Float, error) {
+	switch v := d.number().(type) {
+	case float32:
+		return newBigFloat(float64(v))
+	case float64:
+		return newBigFloat(v)
+	case json.Number:
+		// log2(10) < 10/3 bits per decimal digit.
+		prec := max(64, uint(len(v))*10/3+1)
+		f, _, err := big.ParseFloat(string(v), 10, prec, big.ToNearestEven)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrTypeMismatch, err)
+		}
+		return f, nil
+	}
+
+	r, err := d.Rat()
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Float).SetPrec(64).SetRat(r), nil
+}
+
+// Rat returns the underlying number as an exact *big.Rat.
+func (d *Data) Rat() (*big.Rat, error) {
+	v := d.number()
+	r, ok := toRat(v)
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot convert %s to big.Rat", ErrTypeMismatch, New(v).Kind())
+	}
+	return r, nil
+}
+
+// SetPathBigInt sets the value for the specified path like SetPathE, storing
+// x as a json.Number holding its exact decimal text. A nil x stores null.
+func (d *Data) SetPathBigInt(branch []any, x *big.Int) error {
+	if x == nil {
+		return d.SetPathE(branch, nil)
+	}
+	return d.SetPathE(branch, json.Number(x.String()))
+}
+
+// SetPathBigFloat sets the value for the specified path like SetPathE, storing
+// x as a json.Number with the shortest decimal text that identifies it
+// exactly at its precision. Infinities fail with ErrInexact and a nil x
+// stores null.
+func (d *Data) SetPathBigFloat(branch []any, x *big.Float) error {
+	if x == nil {
+		return d.SetPathE(branch, nil)
+	}
+	if x.IsInf() {
+		return fmt.Errorf("%w: %s", ErrInexact, x)
+	}
+	return d.SetPathE(branch, json.Number(x.Text('g', -1)))
+}
+
+// SetPathRat sets the value for the specified path like SetPathE, storing x
+// as a json.Number holding its exact decimal text. Rationals without a finite
+// decimal expansion, such as 1/3, fail with ErrInexact and a nil x stores null.
+func (d *Data) SetPathRat(branch []any, x *big.Rat) error {
+	if x == nil {
+		return d.SetPathE(branch, nil)
+	}
+	prec, exact := x.FloatPrec()
+	if !exact {
+		return fmt.Errorf("%w: %s", ErrInexact, x.RatString())
+	}
+	return d.SetPathE(branch, json.Number(x.FloatString(prec)))
+}
+
+func newBigFloat(f float64) (*big.Float, error) {
+	if math.IsNaN(f) {
+		return nil, fmt.Errorf("%w: cannot convert NaN to big.Float", ErrTypeMismatch)
+	}
+	return big.NewFloat(f), nil
+}
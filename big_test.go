@@ -0,0 +1,131 @@
+package jester_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestBigInt(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"amount": 123456789012345678901234567890, "exp": 1e30, "ratio": 0.5, "id": "98765432109876543210", "name": "x"}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	v, err := js.Get("amount").BigInt()
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if v.String() != "123456789012345678901234567890" {
+		t.Errorf("got %s", v)
+	}
+
+	if v, err := js.Get("exp").BigInt(); err != nil || v.String() != "1000000000000000000000000000000" {
+		t.Errorf("got %s err %#v", v, err)
+	}
+	if _, err := js.Get("ratio").BigInt(); !errors.Is(err, jester.ErrNotInteger) {
+		t.Errorf("err %#v", err)
+	}
+	if _, err := js.Get("name").BigInt(); !errors.Is(err, jester.ErrTypeMismatch) {
+		t.Errorf("err %#v", err)
+	}
+	if _, err := js.Get("id").BigInt(); !errors.Is(err, jester.ErrTypeMismatch) {
+		t.Errorf("err %#v", err)
+	}
+	if v, err := js.Lenient().Get("id").BigInt(); err != nil || v.String() != "98765432109876543210" {
+		t.Errorf("got %s err %#v", v, err)
+	}
+}
+
+func TestBigFloatAndRat(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"amount": 12345678901234567890.123456789, "third": 0.1}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	f, err := js.Get("amount").BigFloat()
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if s := f.Text('f', 9); s != "12345678901234567890.123456789" {
+		t.Errorf("got %s", s)
+	}
+
+	r, err := js.Get("third").Rat()
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if r.Cmp(big.NewRat(1, 10)) != 0 {
+		t.Errorf("got %s", r)
+	}
+
+	if f, err := jester.New(2.5).BigFloat(); err != nil || f.String() != "2.5" {
+		t.Errorf("got %s err %#v", f, err)
+	}
+	if r, err := jester.New(uint64(1) << 63).Rat(); err != nil || r.RatString() != "9223372036854775808" {
+		t.Errorf("got %s err %#v", r, err)
+	}
+	if _, err := jester.New(nil).Rat(); !errors.Is(err, jester.ErrTypeMismatch) {
+		t.Errorf("err %#v", err)
+	}
+}
+
+func TestSetPathBig(t *testing.T) {
+	js := jester.NewEmpty()
+
+	n, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	if err := js.SetPathBigInt([]any{"int"}, n); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	f, _, _ := big.ParseFloat("12345678901234567890.5", 10, 128, big.ToNearestEven)
+	if err := js.SetPathBigFloat([]any{"float"}, f); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if err := js.SetPathRat([]any{"rat"}, big.NewRat(-1, 8)); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if err := js.SetPathRat([]any{"none"}, nil); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	for key, expected := range map[string]json.Number{
+		"int":   "123456789012345678901234567890",
+		"float": "1.23456789012345678905e+19",
+		"rat":   "-0.125",
+	} {
+		if v := js.Get(key).Interface(); v != expected {
+			t.Errorf("%s: got %#v expected %#v", key, v, expected)
+		}
+	}
+	if !js.Get("none").IsNull() {
+		t.Errorf("got %#v", js.Get("none").Interface())
+	}
+
+	raw, err := json.Marshal(js)
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	back, err := jester.NewJson(raw)
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if v, err := back.Get("int").BigInt(); err != nil || v.Cmp(n) != 0 {
+		t.Errorf("got %s err %#v", v, err)
+	}
+
+	if err := js.SetPathRat([]any{"third"}, big.NewRat(1, 3)); !errors.Is(err, jester.ErrInexact) {
+		t.Errorf("err %#v", err)
+	}
+	if err := js.SetPathBigFloat([]any{"inf"}, new(big.Float).SetInf(false)); !errors.Is(err, jester.ErrInexact) {
+		t.Errorf("err %#v", err)
+	}
+	if js.Has("third") || js.Has("inf") {
+		t.Errorf("got %#v", js.Interface())
+	}
+	if err := jester.New([]any{}).SetPathBigInt([]any{"a"}, n); !errors.Is(err, jester.ErrTypeMismatch) {
+		t.Errorf("err %#v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package jester
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/goccy/go-json"
+)
+
+var ErrNotCanonical = errors.New("jester: value has no canonical JSON form")
+
+// Canonical returns the RFC 8785 JSON Canonicalization Scheme (JCS) encoding
+// of the data: no insignificant whitespace, object members sorted by the
+// UTF-16 code units of their keys, numbers formatted like ECMAScript's
+// Number.prototype.toString and strings escaped minimally. Equal documents
+// produce byte-identical output, which makes it suitable for signing and hashing.
+//
+// Numbers are represented as IEEE 754 doubles as required by the scheme, so
+// integers beyond 2^53 lose precision. NaN, infinities and strings that are
+// not valid UTF-8 cannot be encoded and fail with ErrNotCanonical.
+func (d *Data) Canonical() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, d.data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeCanonical(buf *bytes.Buffer, v any) error {
+	switch x := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(x))
+	case string:
+		return writeCanonicalString(buf, x)
+	case []byte:
+		return writeCanonicalString(buf, base64.StdEncoding.EncodeToString(x))
+	case []any:
+		buf.WriteByte('[')
+		for i, e := range x {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, e); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]any:
+		keys := make([]string, 0, len(x))
+		units := make(map[string][]uint16, len(x))
+		for k := range x {
+			keys = append(keys, k)
+			units[k] = utf16.Encode([]rune(k))
+		}
+		slices.SortFunc(keys, func(a, b string) int {
+			return slices.Compare(units[a], units[b])
+		})
+
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalString(buf, k); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, x[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		if r, ok := toRat(v); ok {
+			f, _ := r.Float64()
+			if _, isNumber := v.(json.Number); isNumber && math.IsInf(f, 0) {
+				return fmt.Errorf("%w: number %s overflows a double", ErrNotCanonical, x)
+			}
+			buf.WriteString(formatES6Number(f))
+			return nil
+		}
+		if f, ok := v.(float64); ok {
+			return fmt.Errorf("%w: number %v", ErrNotCanonical, f)
+		}
+		if f, ok := v.(float32); ok {
+			return fmt.Errorf("%w: number %v", ErrNotCanonical, f)
+		}
+
+		// Anything else is canonicalized through its regular JSON encoding.
+		decoded, err := jsonValue(v)
+		if err != nil {
+			return err
+		}
+		return writeCanonical(buf, decoded)
+	}
+	return nil
+}
+
+// jsonValue converts a value of any other type into the tree representation
+// of its JSON encoding, as NewJson would decode it.
+func jsonValue(v any) (any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// formatES6Number formats f like ECMAScript's Number.prototype.toString: the
+// shortest round-tripping digits, in plain notation for magnitudes in
+// [1e-6, 1e21) and in exponent notation without padding otherwise.
+func formatES6Number(f float64) string {
+	if f == 0 {
+		return "0" // also for negative zero
+	}
+	if abs := math.Abs(f); abs >= 1e-6 && abs < 1e21 {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	mantissa, exp, _ := strings.Cut(s, "e")
+	sign, digits := exp[:1], strings.TrimLeft(exp[1:], "0")
+	return mantissa + "e" + sign + digits
+}
+
+func writeCanonicalString(buf *bytes.Buffer, s string) error {
+	if !utf8.ValidString(s) {
+		return fmt.Errorf("%w: invalid UTF-8 in string %q", ErrNotCanonical, s)
+	}
+
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+				continue
+			}
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+	return nil
+}
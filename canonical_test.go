@@ -0,0 +1,101 @@
+package jester_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestCanonical(t *testing.T) {
+	// The example from RFC 8785, section 3.2.2.
+	d, err := jester.NewJson([]byte(`{"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+		"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+		"literals": [null, true, false]}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	got, err := d.Canonical()
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	expected := `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`
+	if string(got) != expected {
+		t.Errorf("got %s expected %s", got, expected)
+	}
+}
+
+func TestCanonicalSorting(t *testing.T) {
+	// The example from RFC 8785, section 3.2.3: keys sort by UTF-16 code units.
+	d, err := jester.NewJson([]byte(`{"\u20ac": "Euro Sign", "\r": "Carriage Return", "\ufb33": "Hebrew Letter Dalet With Dagesh",
+		"1": "One", "\ud83d\ude00": "Emoji: Grinning Face", "\u0080": "Control", "\u00f6": "Latin Small Letter O With Diaeresis"}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	got, err := d.Canonical()
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	expected := "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\"," +
+		"\"\u20ac\":\"Euro Sign\",\"\U0001f600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}"
+	if string(got) != expected {
+		t.Errorf("got %s expected %s", got, expected)
+	}
+}
+
+func TestCanonicalNumbers(t *testing.T) {
+	for _, tc := range []struct {
+		in       any
+		expected string
+	}{
+		{0.0, "0"},
+		{math.Copysign(0, -1), "0"},
+		{1e21, "1e+21"},
+		{1e20, "100000000000000000000"},
+		{1e-7, "1e-7"},
+		{0.000001, "0.000001"},
+		{-1.5e-10, "-1.5e-10"},
+		{9007199254740992, "9007199254740992"},
+		{uint8(7), "7"},
+		{json.Number("295147905179352830000"), "295147905179352830000"},
+		{json.Number("-5.0E+2"), "-500"},
+		{float32(0.1), "0.10000000149011612"},
+	} {
+		got, err := jester.New(tc.in).Canonical()
+		if err != nil {
+			t.Fatalf("err %#v", err)
+		}
+		if string(got) != tc.expected {
+			t.Errorf("%#v: got %s expected %s", tc.in, got, tc.expected)
+		}
+	}
+}
+
+func TestCanonicalErrors(t *testing.T) {
+	for _, v := range []any{math.NaN(), math.Inf(-1), json.Number("1e400"), "\xff", map[string]any{"\xff": 1}} {
+		if _, err := jester.New(v).Canonical(); !errors.Is(err, jester.ErrNotCanonical) {
+			t.Errorf("%#v: err %#v", v, err)
+		}
+	}
+}
+
+func TestCanonicalOther(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+		ID   int    `json:"id"`
+	}
+	d := jester.New(map[string]any{"user": user{"a", 1}, "raw": []byte("hi"), "html": "<&>"})
+
+	got, err := d.Canonical()
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	expected := `{"html":"<&>","raw":"aGk=","user":{"id":1,"name":"a"}}`
+	if string(got) != expected {
+		t.Errorf("got %s expected %s", got, expected)
+	}
+}
@@ -0,0 +1,101 @@
+package jester
+
+import (
+	"slices"
+	"strconv"
+)
+
+// CloneOptions configures CloneWith.
+type CloneOptions struct {
+	// MaxDepth limits how many levels of objects and arrays are copied; deeper
+	// containers are shared with the original. Zero means no limit, and a
+	// depth of 1 copies only the root container.
+	MaxDepth int
+	// Path restricts copying to the containers along Path and the subtree at
+	// Path, which is copied according to MaxDepth. Everything else is shared
+	// with the original, which is enough to safely modify the subtree of a
+	// large document without copying all of it.
+	Path Path
+}
+
+// Clone returns a deep copy of the data that shares no objects, arrays or
+// byte slices with the original.
+func (d *Data) Clone() *Data {
+	return d.CloneWith(CloneOptions{})
+}
+
+// CloneShallow returns a copy of the data in which only the root object or
+// array is copied; nested values are shared with the original.
+func (d *Data) CloneShallow() *Data {
+	return d.CloneWith(CloneOptions{MaxDepth: 1})
+}
+
+// CloneWith returns a copy of the data as configured by opts.
+func (d *Data) CloneWith(opts CloneOptions) *Data {
+	depth := opts.MaxDepth
+	if depth <= 0 {
+		depth = -1
+	}
+
+	c := *d
+	c.data = clonePath(d.data, opts.Path, depth)
+	return &c
+}
+
+// clonePath copies the containers along path and the subtree at its end.
+func clonePath(v any, path Path, depth int) any {
+	if len(path) == 0 {
+		return copyValue(v, depth)
+	}
+
+	switch x := v.(type) {
+	case map[string]any:
+		k, ok := path[0].(string)
+		if i, isInt := path[0].(int); isInt {
+			k, ok = strconv.Itoa(i), true
+		}
+		m := copyValue(x, 1).(map[string]any)
+		if child, exists := m[k]; ok && exists {
+			m[k] = clonePath(child, path[1:], depth)
+		}
+		return m
+	case []any:
+		s := copyValue(x, 1).([]any)
+		if i, ok := path[0].(int); ok && i >= 0 && i < len(s) {
+			s[i] = clonePath(s[i], path[1:], depth)
+		}
+		return s
+	}
+
+	return v
+}
+
+// copyValue copies depth levels of containers of v; a negative depth copies all of them.
+func copyValue(v any, depth int) any {
+	if depth == 0 {
+		return v
+	}
+
+	switch x := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(x))
+		for k, e := range x {
+			m[k] = copyValue(e, depth-1)
+		}
+		return m
+	case []any:
+		s := make([]any, len(x))
+		for i, e := range x {
+			s[i] = copyValue(e, depth-1)
+		}
+		return s
+	case []byte:
+		return slices.Clone(x)
+	}
+	return v
+}
+
+// deepCopy returns a copy of v that shares no maps, slices or byte slices with it.
+func deepCopy(v any) any {
+	return copyValue(v, -1)
+}
@@ -0,0 +1,92 @@
+package jester_test
+
+import (
+	"testing"
+
+	"github.com/lb-selfbot/go-jester"
+)
+
+const cloneJSON = `{"guild": {"name": "g", "members": [{"id": 1}]}, "other": {"x": [1]}, "raw": null}`
+
+func TestClone(t *testing.T) {
+	js, err := jester.NewJson([]byte(cloneJSON))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	js.Set("raw", []byte("abc"))
+
+	c := js.Clone()
+	c.Get("guild").Set("name", "changed")
+	c.Get("guild", "members", 0).Set("id", 2)
+	c.Get("raw").MustBytes()[0] = 'x'
+	if err := c.Append([]any{"other", "x"}, 2); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	if s := js.Get("guild", "name").MustString(); s != "g" {
+		t.Errorf("original modified: %#v", s)
+	}
+	if i := js.Get("guild", "members", 0, "id").MustInt(); i != 1 {
+		t.Errorf("original modified: %#v", i)
+	}
+	if b := js.Get("raw").MustBytes(); string(b) != "abc" {
+		t.Errorf("original modified: %#v", string(b))
+	}
+	if l := js.Get("other", "x").Len(); l != 1 {
+		t.Errorf("original modified: %#v", l)
+	}
+
+	if missing := js.Get("nope").Clone(); missing.Exists() {
+		t.Errorf("clone of a missing value should be missing")
+	}
+}
+
+func TestCloneShallow(t *testing.T) {
+	js, err := jester.NewJson([]byte(cloneJSON))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	c := js.CloneShallow()
+	c.Set("added", true)
+	c.Get("guild").Set("name", "shared")
+
+	if js.Has("added") {
+		t.Errorf("root was not copied")
+	}
+	if s := js.Get("guild", "name").MustString(); s != "shared" {
+		t.Errorf("nested values should be shared, got %#v", s)
+	}
+}
+
+func TestCloneWith(t *testing.T) {
+	js, err := jester.NewJson([]byte(cloneJSON))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	c := js.CloneWith(jester.CloneOptions{MaxDepth: 2})
+	c.Get("guild").Set("name", "copied")
+	c.Get("guild", "members", 0).Set("id", 5)
+	if s := js.Get("guild", "name").MustString(); s != "g" {
+		t.Errorf("second level should be copied, got %#v", s)
+	}
+	if i := js.Get("guild", "members", 0, "id").MustInt(); i != 5 {
+		t.Errorf("fourth level should be shared, got %#v", i)
+	}
+
+	js, _ = jester.NewJson([]byte(cloneJSON))
+	c = js.CloneWith(jester.CloneOptions{Path: jester.Path{"guild", "members"}})
+	c.Get("guild", "members", 0).Set("id", 9)
+	c.Get("guild").Set("name", "copied")
+	c.Get("other").Set("y", true)
+	if i := js.Get("guild", "members", 0, "id").MustInt(); i != 1 {
+		t.Errorf("subtree should be copied, got %#v", i)
+	}
+	if s := js.Get("guild", "name").MustString(); s != "g" {
+		t.Errorf("containers along the path should be copied, got %#v", s)
+	}
+	if !js.Has("other", "y") {
+		t.Errorf("values off the path should be shared")
+	}
+}
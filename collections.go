@@ -0,0 +1,163 @@
+package jester
+
+// IntSlice returns the underlying data as a []int, converting each element
+// like Int. Null elements become 0.
+func (d *Data) IntSlice() ([]int, error) {
+	return sliceOf(d, (*Data).Int)
+}
+
+// MustIntSlice returns the underlying data as a []int with optional default value.
+func (d *Data) MustIntSlice(args ...[]int) []int {
+	return mustValue(d.IntSlice, args)
+}
+
+// Int64Slice returns the underlying data as a []int64, converting each element
+// like Int64. Null elements become 0.
+func (d *Data) Int64Slice() ([]int64, error) {
+	return sliceOf(d, (*Data).Int64)
+}
+
+// MustInt64Slice returns the underlying data as a []int64 with optional default value.
+func (d *Data) MustInt64Slice(args ...[]int64) []int64 {
+	return mustValue(d.Int64Slice, args)
+}
+
+// Uint64Slice returns the underlying data as a []uint64, converting each element
+// like Uint64. Null elements become 0.
+func (d *Data) Uint64Slice() ([]uint64, error) {
+	return sliceOf(d, (*Data).Uint64)
+}
+
+// MustUint64Slice returns the underlying data as a []uint64 with optional default value.
+func (d *Data) MustUint64Slice(args ...[]uint64) []uint64 {
+	return mustValue(d.Uint64Slice, args)
+}
+
+// Float64Slice returns the underlying data as a []float64, converting each
+// element like Float64. Null elements become 0.
+func (d *Data) Float64Slice() ([]float64, error) {
+	return sliceOf(d, (*Data).Float64)
+}
+
+// MustFloat64Slice returns the underlying data as a []float64 with optional default value.
+func (d *Data) MustFloat64Slice(args ...[]float64) []float64 {
+	return mustValue(d.Float64Slice, args)
+}
+
+// BoolSlice returns the underlying data as a []bool. Null elements become false.
+func (d *Data) BoolSlice() ([]bool, error) {
+	return sliceOf(d, (*Data).Bool)
+}
+
+// MustBoolSlice returns the underlying data as a []bool with optional default value.
+func (d *Data) MustBoolSlice(args ...[]bool) []bool {
+	return mustValue(d.BoolSlice, args)
+}
+
+// DataSlice returns the elements of the underlying array as Data. Null
+// elements are returned as existing null values, never as nil.
+func (d *Data) DataSlice() ([]*Data, error) {
+	s, err := d.Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*Data, len(s))
+	for i, v := range s {
+		out[i] = d.child(v)
+	}
+	return out, nil
+}
+
+// MustDataSlice returns the elements of the underlying array as Data with optional default value.
+func (d *Data) MustDataSlice(args ...[]*Data) []*Data {
+	return mustValue(d.DataSlice, args)
+}
+
+// StringMap returns the underlying data as a map[string]string. Null members become "".
+func (d *Data) StringMap() (map[string]string, error) {
+	m, err := d.Map()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if v == nil {
+			out[k] = ""
+			continue
+		}
+
+		str, ok := v.(string)
+		if !ok {
+			return nil, ErrTypeMismatch
+		}
+
+		out[k] = str
+	}
+	return out, nil
+}
+
+// MustStringMap returns the underlying data as a map[string]string with optional default value.
+func (d *Data) MustStringMap(args ...map[string]string) map[string]string {
+	return mustValue(d.StringMap, args)
+}
+
+// DataMap returns the members of the underlying object as Data. Null members
+// are returned as existing null values, never as nil.
+func (d *Data) DataMap() (map[string]*Data, error) {
+	m, err := d.Map()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]*Data, len(m))
+	for k, v := range m {
+		out[k] = d.child(v)
+	}
+	return out, nil
+}
+
+// MustDataMap returns the members of the underlying object as Data with optional default value.
+func (d *Data) MustDataMap(args ...map[string]*Data) map[string]*Data {
+	return mustValue(d.DataMap, args)
+}
+
+// sliceOf converts every element of the underlying array with conv, turning
+// null elements into the zero value.
+func sliceOf[T any](d *Data, conv func(*Data) (T, error)) ([]T, error) {
+	s, err := d.Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]T, 0, len(s))
+	for _, v := range s {
+		if v == nil {
+			var zero T
+			out = append(out, zero)
+			continue
+		}
+
+		x, err := conv(d.child(v))
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, x)
+	}
+	return out, nil
+}
+
+// mustValue returns the result of get, or the optional default value if get fails.
+func mustValue[T any](get func() (T, error), args []T) T {
+	var value T
+
+	if v, err := get(); err == nil {
+		value = v
+	} else if len(args) > 0 {
+		value = args[0]
+	}
+
+	return value
+}
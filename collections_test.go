@@ -0,0 +1,83 @@
+package jester_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestTypedSlices(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"roles": [1, "2"], "ids": [1, 2, null, 3], "ratios": [0.5, 1],
+		"flags": [true, null, false], "perms": {"read": "yes", "write": null}, "bad": {"a": 1}}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	if v, err := js.Get("ids").IntSlice(); err != nil || !reflect.DeepEqual(v, []int{1, 2, 0, 3}) {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := js.Get("ids").Int64Slice(); err != nil || !reflect.DeepEqual(v, []int64{1, 2, 0, 3}) {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := js.Get("ids").Uint64Slice(); err != nil || !reflect.DeepEqual(v, []uint64{1, 2, 0, 3}) {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := js.Get("ratios").Float64Slice(); err != nil || !reflect.DeepEqual(v, []float64{0.5, 1}) {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := js.Get("flags").BoolSlice(); err != nil || !reflect.DeepEqual(v, []bool{true, false, false}) {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := js.Get("perms").StringMap(); err != nil || !reflect.DeepEqual(v, map[string]string{"read": "yes", "write": ""}) {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+
+	if _, err := js.Get("roles").IntSlice(); !errors.Is(err, jester.ErrTypeMismatch) {
+		t.Errorf("err %#v", err)
+	}
+	if _, err := js.Get("bad").StringMap(); !errors.Is(err, jester.ErrTypeMismatch) {
+		t.Errorf("err %#v", err)
+	}
+	if _, err := js.Get("perms").BoolSlice(); !errors.Is(err, jester.ErrTypeMismatch) {
+		t.Errorf("err %#v", err)
+	}
+
+	if v := js.Get("roles").MustInt64Slice([]int64{9}); !reflect.DeepEqual(v, []int64{9}) {
+		t.Errorf("got %#v", v)
+	}
+	if v := js.Get("missing").MustIntSlice(); v != nil {
+		t.Errorf("got %#v", v)
+	}
+	if v := js.Get("bad").MustStringMap(map[string]string{"x": "y"}); !reflect.DeepEqual(v, map[string]string{"x": "y"}) {
+		t.Errorf("got %#v", v)
+	}
+}
+
+func TestDataCollections(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"mixed": [1, {"a": 2}, null], "perms": {"read": "yes", "write": null}}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	s := js.Get("mixed").MustDataSlice()
+	if len(s) != 3 || s[0].MustInt() != 1 || s[1].Get("a").MustInt() != 2 || !s[2].IsNull() {
+		t.Errorf("got %#v", s)
+	}
+
+	m, err := js.Get("perms").DataMap()
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if len(m) != 2 || m["read"].MustString() != "yes" || !m["write"].IsNull() {
+		t.Errorf("got %#v", m)
+	}
+
+	if _, err := js.Get("perms").DataSlice(); !errors.Is(err, jester.ErrTypeMismatch) {
+		t.Errorf("err %#v", err)
+	}
+	if v := js.Get("mixed").MustDataMap(); v != nil {
+		t.Errorf("got %#v", v)
+	}
+}
@@ -0,0 +1,308 @@
+package jester
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// ChangeKind classifies a Change.
+type ChangeKind int
+
+const (
+	// ChangeAdded is a value that exists only in the second document.
+	ChangeAdded ChangeKind = iota
+	// ChangeRemoved is a value that exists only in the first document.
+	ChangeRemoved
+	// ChangeModified is a value of the same kind that differs between the documents.
+	ChangeModified
+	// ChangeTypeChanged is a value whose Kind differs between the documents.
+	ChangeTypeChanged
+	// ChangeMoved is an array element matched by identity key that changed
+	// position relative to the other matched elements.
+	ChangeMoved
+)
+
+var changeKindNames = [...]string{
+	ChangeAdded:       "added",
+	ChangeRemoved:     "removed",
+	ChangeModified:    "modified",
+	ChangeTypeChanged: "type-changed",
+	ChangeMoved:       "moved",
+}
+
+// String returns the name of the change kind.
+func (k ChangeKind) String() string {
+	if k >= 0 && int(k) < len(changeKindNames) {
+		return changeKindNames[k]
+	}
+	return "unknown"
+}
+
+// Change is a single difference between two documents.
+type Change struct {
+	// Path is the location of the value in the second document, or in the
+	// first document for ChangeRemoved. The two only differ below arrays
+	// compared by identity, see DiffArrayByKey.
+	Path Path
+	Kind ChangeKind
+	// Old is the value in the first document; it does not exist for ChangeAdded.
+	Old *Data
+	// New is the value in the second document; it does not exist for ChangeRemoved.
+	New *Data
+	// From is the path of the element in the first document for ChangeMoved.
+	From Path
+}
+
+// String returns a one-line description of the change.
+func (c Change) String() string {
+	path := displayPath(c.Path)
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %s", path, compactJSON(c.New))
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %s", path, compactJSON(c.Old))
+	case ChangeTypeChanged:
+		return fmt.Sprintf("~ %s: %s (%s) -> %s (%s)", path, compactJSON(c.Old), c.Old.Kind(), compactJSON(c.New), c.New.Kind())
+	case ChangeMoved:
+		return fmt.Sprintf("> %s: moved from %s", path, displayPath(c.From))
+	}
+	return fmt.Sprintf("~ %s: %s -> %s", path, compactJSON(c.Old), compactJSON(c.New))
+}
+
+// CompareOptions configures CompareWith.
+type CompareOptions struct {
+	// ArrayKeys maps array locations to the path of the identity field of
+	// their elements. Elements of such arrays are matched by identity instead
+	// of by index, see DiffArrayByKey. Locations are written like Path.String
+	// with every array index replaced by [*], e.g. "guilds[*].members";
+	// the root array is "".
+	ArrayKeys map[string]Path
+}
+
+// Compare reports the differences between a and b, in document order with
+// object members sorted by key. Objects are compared member by member and
+// arrays index by index; numbers are compared by value as in Equal.
+func Compare(a, b *Data) []Change {
+	return CompareWith(a, b, CompareOptions{})
+}
+
+// CompareWith is like Compare but compares the arrays listed in
+// opts.ArrayKeys by element identity.
+func CompareWith(a, b *Data, opts CompareOptions) []Change {
+	return compareValues(nil, Path{}, Path{}, a, b, &opts)
+}
+
+// DiffArrayByKey compares two arrays whose elements are identified by the
+// value at key, e.g. DiffArrayByKey(a, b, "id") for arrays of objects with an
+// "id" member. Elements are matched by identity rather than by index:
+// unmatched elements are reported as added or removed, matched elements that
+// changed order as moved, and differences inside matched elements are
+// reported at their path in b. Removed elements are reported at their path
+// in a. Elements without an identity are matched by their order among the
+// other elements without one.
+func DiffArrayByKey(a, b *Data, key ...any) []Change {
+	return CompareWith(a, b, CompareOptions{ArrayKeys: map[string]Path{"": key}})
+}
+
+// compareValues appends the differences between a and b, located at pathA
+// in the first document and at pathB in the second.
+func compareValues(changes []Change, pathA, pathB Path, a, b *Data, opts *CompareOptions) []Change {
+	switch {
+	case !a.Exists() && !b.Exists():
+		return changes
+	case !a.Exists():
+		return append(changes, Change{Path: pathB, Kind: ChangeAdded, Old: a, New: b})
+	case !b.Exists():
+		return append(changes, Change{Path: pathA, Kind: ChangeRemoved, Old: a, New: b})
+	}
+
+	ka, kb := a.Kind(), b.Kind()
+	if ka != kb {
+		return append(changes, Change{Path: pathB, Kind: ChangeTypeChanged, Old: a, New: b})
+	}
+
+	switch ka {
+	case KindObject:
+		ma, mb := a.MustMap(), b.MustMap()
+		keys := make(map[string]any, len(ma)+len(mb))
+		for k := range ma {
+			keys[k] = nil
+		}
+		for k := range mb {
+			keys[k] = nil
+		}
+		for _, k := range sortedKeys(keys) {
+			changes = compareValues(changes, pathA.child(k), pathB.child(k), a.get(k), b.get(k), opts)
+		}
+		return changes
+	case KindArray:
+		if key, ok := opts.ArrayKeys[arrayPattern(pathB)]; ok {
+			return compareKeyedArrays(changes, pathA, pathB, a, b, key, opts)
+		}
+		for i := range max(a.Len(), b.Len()) {
+			changes = compareValues(changes, pathA.child(i), pathB.child(i), a.get(i), b.get(i), opts)
+		}
+		return changes
+	}
+
+	if Equal(a, b) {
+		return changes
+	}
+	return append(changes, Change{Path: pathB, Kind: ChangeModified, Old: a, New: b})
+}
+
+// compareKeyedArrays matches the elements of the arrays a and b by the
+// identity at key. Removed elements are reported first at their path in a,
+// followed by the elements of b in order.
+func compareKeyedArrays(changes []Change, pathA, pathB Path, a, b *Data, key Path, opts *CompareOptions) []Change {
+	x, y := a.MustSlice(), b.MustSlice()
+	identity := func(v any) string {
+		if id, ok := New(v).Lookup(key...); ok {
+			if k, ok := identityKey(id.data); ok {
+				return "k" + k
+			}
+		}
+		return "-" // no identity; matched by order
+	}
+
+	// Queue the indexes of x per identity, so that duplicates are matched in order.
+	queues := make(map[string][]int)
+	for i, v := range x {
+		id := identity(v)
+		queues[id] = append(queues[id], i)
+	}
+
+	match := make([]int, len(y)) // index in x matched with each element of y, or -1
+	matched := make([]bool, len(x))
+	for j, v := range y {
+		match[j] = -1
+		id := identity(v)
+		if q := queues[id]; len(q) > 0 {
+			match[j], queues[id] = q[0], q[1:]
+			matched[q[0]] = true
+		}
+	}
+
+	for i, v := range x {
+		if !matched[i] {
+			changes = append(changes, Change{Path: pathA.child(i), Kind: ChangeRemoved, Old: a.child(v), New: missing()})
+		}
+	}
+
+	stable := stableMatches(match)
+	for j, v := range y {
+		i := match[j]
+		if i < 0 {
+			changes = append(changes, Change{Path: pathB.child(j), Kind: ChangeAdded, Old: missing(), New: b.child(v)})
+			continue
+		}
+		if !stable[j] {
+			changes = append(changes, Change{Path: pathB.child(j), Kind: ChangeMoved, Old: a.child(x[i]), New: b.child(v), From: pathA.child(i)})
+		}
+		changes = compareValues(changes, pathA.child(i), pathB.child(j), a.child(x[i]), b.child(v), opts)
+	}
+
+	return changes
+}
+
+// stableMatches marks the matched elements that keep their relative order:
+// those on a longest increasing subsequence of their original indexes.
+// Every other matched element has moved.
+func stableMatches(match []int) []bool {
+	var tails []int // tails[k] is the position in match ending the best subsequence of length k+1
+	prev := make([]int, len(match))
+	for j, i := range match {
+		if i < 0 {
+			continue
+		}
+		k, _ := slices.BinarySearchFunc(tails, i, func(t, target int) int {
+			return cmp.Compare(match[t], target)
+		})
+		if k > 0 {
+			prev[j] = tails[k-1]
+		} else {
+			prev[j] = -1
+		}
+		if k == len(tails) {
+			tails = append(tails, j)
+		} else {
+			tails[k] = j
+		}
+	}
+
+	stable := make([]bool, len(match))
+	if len(tails) > 0 {
+		for j := tails[len(tails)-1]; j >= 0; j = prev[j] {
+			stable[j] = true
+		}
+	}
+	return stable
+}
+
+// arrayPattern returns the location of path as used in CompareOptions.ArrayKeys.
+func arrayPattern(path Path) string {
+	var b strings.Builder
+	for i, seg := range path {
+		if _, ok := seg.(int); ok {
+			b.WriteString("[*]")
+			continue
+		}
+		s := Path{seg}.String()
+		if i > 0 && !strings.HasPrefix(s, "[") {
+			b.WriteByte('.')
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
+
+// FormatChanges renders changes in a unified-diff-like text format, with a
+// hunk per changed path:
+//
+//	--- a
+//	+++ b
+//	@@ guild.name @@
+//	- "old"
+//	+ "new"
+func FormatChanges(changes []Change) string {
+	var b strings.Builder
+	b.WriteString("--- a\n+++ b\n")
+	for _, c := range changes {
+		fmt.Fprintf(&b, "@@ %s @@", displayPath(c.Path))
+		switch c.Kind {
+		case ChangeTypeChanged:
+			fmt.Fprintf(&b, " %s -> %s\n", c.Old.Kind(), c.New.Kind())
+		case ChangeMoved:
+			fmt.Fprintf(&b, " moved from %s\n", displayPath(c.From))
+			continue
+		default:
+			b.WriteByte('\n')
+		}
+		if c.Old.Exists() {
+			fmt.Fprintf(&b, "- %s\n", compactJSON(c.Old))
+		}
+		if c.New.Exists() {
+			fmt.Fprintf(&b, "+ %s\n", compactJSON(c.New))
+		}
+	}
+	return b.String()
+}
+
+func displayPath(p Path) string {
+	if len(p) == 0 {
+		return "$"
+	}
+	return p.String()
+}
+
+func compactJSON(d *Data) string {
+	raw, err := json.Marshal(d.data)
+	if err != nil {
+		return fmt.Sprintf("%v", d.data)
+	}
+	return string(raw)
+}
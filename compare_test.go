@@ -0,0 +1,137 @@
+package jester_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestCompare(t *testing.T) {
+	a, err := jester.NewJson([]byte(`{"name": "g", "count": 1, "tags": ["a", "b"], "owner": {"id": 1}, "gone": true, "same": [1]}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	b, err := jester.NewJson([]byte(`{"name": "h", "count": 1.0, "tags": ["a"], "owner": "nobody", "new": null, "same": [1]}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	changes := jester.Compare(a, b)
+
+	type summary struct {
+		path string
+		kind jester.ChangeKind
+	}
+	var got []summary
+	for _, c := range changes {
+		got = append(got, summary{c.Path.String(), c.Kind})
+	}
+
+	expected := []summary{
+		{"gone", jester.ChangeRemoved},
+		{"name", jester.ChangeModified},
+		{"new", jester.ChangeAdded},
+		{"owner", jester.ChangeTypeChanged},
+		{"tags[1]", jester.ChangeRemoved},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %#v expected %#v", got, expected)
+	}
+
+	if s := changes[1].Old.MustString(); s != "g" {
+		t.Errorf("got %#v", s)
+	}
+	if s := changes[1].New.MustString(); s != "h" {
+		t.Errorf("got %#v", s)
+	}
+	if changes[0].New.Exists() || !changes[2].New.IsNull() {
+		t.Errorf("unexpected old/new values")
+	}
+
+	if c := jester.Compare(a, a); len(c) != 0 {
+		t.Errorf("got %#v", c)
+	}
+}
+
+func TestFormatChanges(t *testing.T) {
+	a, _ := jester.NewJson([]byte(`{"guild": {"name": "old", "id": 1}}`))
+	b, _ := jester.NewJson([]byte(`{"guild": {"name": "new", "id": "1"}, "x": [1]}`))
+
+	out := jester.FormatChanges(jester.Compare(a, b))
+	expected := `--- a
++++ b
+@@ guild.id @@ number -> string
+- 1
++ "1"
+@@ guild.name @@
+- "old"
++ "new"
+@@ x @@
++ [1]
+`
+	if out != expected {
+		t.Errorf("got %s expected %s", out, expected)
+	}
+
+	c := jester.Compare(a, b)[1]
+	if s := c.String(); s != `~ guild.name: "old" -> "new"` {
+		t.Errorf("got %#v", s)
+	}
+}
+
+func TestDiffArrayByKey(t *testing.T) {
+	a, err := jester.NewJson([]byte(`[{"id": 1, "name": "a"}, {"id": 2, "name": "b"}, {"id": 3, "name": "c"}, {"id": 4}]`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	b, err := jester.NewJson([]byte(`[{"id": 0, "name": "new"}, {"id": 3, "name": "c"}, {"id": 1, "name": "a"}, {"id": 2, "name": "B"}]`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	var got []string
+	for _, c := range jester.DiffArrayByKey(a, b, "id") {
+		got = append(got, c.String())
+	}
+
+	expected := []string{
+		`- [3]: {"id":4}`,
+		`+ [0]: {"id":0,"name":"new"}`,
+		`> [1]: moved from [2]`,
+		`~ [3].name: "b" -> "B"`,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %#v expected %#v", got, expected)
+	}
+}
+
+func TestCompareWithArrayKeys(t *testing.T) {
+	a, err := jester.NewJson([]byte(`{"guilds": [{"id": "g1", "members": [{"user": {"id": 1}}, {"user": {"id": 2}}]}]}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	b, err := jester.NewJson([]byte(`{"guilds": [{"id": "g0"}, {"id": "g1", "members": [{"user": {"id": 2}, "nick": "x"}]}]}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	changes := jester.CompareWith(a, b, jester.CompareOptions{ArrayKeys: map[string]jester.Path{
+		"guilds":            {"id"},
+		"guilds[*].members": {"user", "id"},
+	}})
+
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+
+	expected := []string{
+		`+ guilds[0]: {"id":"g0"}`,
+		`- guilds[0].members[0]: {"user":{"id":1}}`,
+		`+ guilds[1].members[0].nick: "x"`,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %#v expected %#v", got, expected)
+	}
+}
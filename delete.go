@@ -0,0 +1,97 @@
+package jester
+
+import (
+	"slices"
+	"strconv"
+)
+
+// DeletePath removes the object member or array element at the specified path
+// and reports whether anything was removed. Removing an array element shifts
+// the following elements down. The root itself cannot be deleted.
+func (d *Data) DeletePath(branch ...any) bool {
+	return d.deletePath(branch, false)
+}
+
+// PrunePath is like DeletePath but also removes the objects and arrays along
+// the path that become empty as a result, stopping at the root.
+func (d *Data) PrunePath(branch ...any) bool {
+	return d.deletePath(branch, true)
+}
+
+func (d *Data) deletePath(branch []any, prune bool) bool {
+	path, err := NewPath(branch...)
+	if err != nil || len(path) == 0 {
+		return false
+	}
+
+	root, removed := deletePath(d.data, path, 0, prune)
+	if removed {
+		d.data = root
+	}
+	return removed
+}
+
+func deletePath(node any, path Path, depth int, prune bool) (any, bool) {
+	last := depth == len(path)-1
+
+	switch n := node.(type) {
+	case map[string]any:
+		var k string
+		switch key := path[depth].(type) {
+		case string:
+			k = key
+		case int:
+			k = strconv.Itoa(key)
+		}
+
+		child, ok := n[k]
+		if !ok {
+			return n, false
+		}
+		if last {
+			delete(n, k)
+			return n, true
+		}
+
+		child, removed := deletePath(child, path, depth+1, prune)
+		if !removed {
+			return n, false
+		}
+		if prune && isEmptyContainer(child) {
+			delete(n, k)
+		} else {
+			n[k] = child
+		}
+		return n, true
+	case []any:
+		idx, ok := path[depth].(int)
+		if !ok || idx < 0 || idx >= len(n) {
+			return n, false
+		}
+		if last {
+			return slices.Delete(n, idx, idx+1), true
+		}
+
+		child, removed := deletePath(n[idx], path, depth+1, prune)
+		if !removed {
+			return n, false
+		}
+		if prune && isEmptyContainer(child) {
+			return slices.Delete(n, idx, idx+1), true
+		}
+		n[idx] = child
+		return n, true
+	}
+
+	return node, false
+}
+
+func isEmptyContainer(v any) bool {
+	switch x := v.(type) {
+	case map[string]any:
+		return len(x) == 0
+	case []any:
+		return len(x) == 0
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package jester_test
+
+import (
+	"testing"
+
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestDeletePath(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"guild": {"members": [{"id": 1}, {"id": 2}, {"id": 3}], "name": "g"}}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	if !js.DeletePath("guild", "members", 1) {
+		t.Errorf("expected removal")
+	}
+	assertJSON(t, js.Get("guild", "members"), `[{"id": 1}, {"id": 3}]`)
+
+	if !js.DeletePath("guild", "members", int64(0), "id") {
+		t.Errorf("expected removal")
+	}
+	assertJSON(t, js.Get("guild", "members"), `[{}, {"id": 3}]`)
+
+	for _, path := range [][]any{
+		{"guild", "missing"},
+		{"guild", "members", 5},
+		{"guild", "members", -1},
+		{"guild", "members", "x"},
+		{"guild", "name", "x"},
+		{"nope", "deeper"},
+		{},
+	} {
+		if js.DeletePath(path...) {
+			t.Errorf("%#v: unexpected removal", path)
+		}
+	}
+
+	assertJSON(t, js, `{"guild": {"members": [{}, {"id": 3}], "name": "g"}}`)
+}
+
+func TestPrunePath(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"a": {"b": [{"c": 1}], "keep": true}, "x": {"y": {"z": 1}}}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	if !js.PrunePath("a", "b", 0, "c") {
+		t.Errorf("expected removal")
+	}
+	assertJSON(t, js, `{"a": {"keep": true}, "x": {"y": {"z": 1}}}`)
+
+	if !js.PrunePath("x", "y", "z") {
+		t.Errorf("expected removal")
+	}
+	assertJSON(t, js, `{"a": {"keep": true}}`)
+
+	if !js.PrunePath("a", "keep") {
+		t.Errorf("expected removal")
+	}
+	assertJSON(t, js, `{}`)
+}
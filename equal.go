@@ -0,0 +1,162 @@
+package jester
+
+import (
+	"bytes"
+	"encoding/base64"
+	"math"
+	"reflect"
+)
+
+// EqualOptions configures EqualOpts.
+type EqualOptions struct {
+	// BytesAsBase64 treats a []byte as equal to a string holding its standard
+	// base64 encoding, which is how byte slices are marshaled to JSON.
+	BytesAsBase64 bool
+	// FloatTolerance is the largest absolute difference at which two numbers
+	// are still considered equal. Zero compares numbers exactly.
+	FloatTolerance float64
+	// UnorderedArrays compares arrays as multisets, ignoring element order.
+	UnorderedArrays bool
+}
+
+// Equal reports whether a and b hold the same JSON value. Numbers are compared
+// by value, so json.Number("1"), int(1), float64(1) and uint8(1) are all equal.
+// A missing value is only equal to another missing value.
+func Equal(a, b *Data) bool {
+	return EqualOpts(a, b, EqualOptions{})
+}
+
+// EqualOpts is like Equal but compares according to opts.
+func EqualOpts(a, b *Data, opts EqualOptions) bool {
+	if a.missing || b.missing {
+		return a.missing == b.missing
+	}
+	return valuesEqual(a.data, b.data, &opts)
+}
+
+func valuesEqual(a, b any, opts *EqualOptions) bool {
+	switch x := a.(type) {
+	case nil:
+		return b == nil
+	case bool:
+		y, ok := b.(bool)
+		return ok && x == y
+	case string:
+		if y, ok := b.([]byte); ok && opts.BytesAsBase64 {
+			return x == base64.StdEncoding.EncodeToString(y)
+		}
+		y, ok := b.(string)
+		return ok && x == y
+	case []byte:
+		if y, ok := b.(string); ok && opts.BytesAsBase64 {
+			return base64.StdEncoding.EncodeToString(x) == y
+		}
+		y, ok := b.([]byte)
+		return ok && bytes.Equal(x, y)
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		if opts.UnorderedArrays {
+			return unorderedEqual(x, y, opts)
+		}
+		for i := range x {
+			if !valuesEqual(x[i], y[i], opts) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, xv := range x {
+			yv, ok := y[k]
+			if !ok || !valuesEqual(xv, yv, opts) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if ra, ok := toRat(a); ok {
+		rb, ok := toRat(b)
+		if !ok {
+			return false
+		}
+		if opts.FloatTolerance > 0 {
+			fa, _ := ra.Float64()
+			fb, _ := rb.Float64()
+			return math.Abs(fa-fb) <= opts.FloatTolerance
+		}
+		return ra.Cmp(rb) == 0
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// unorderedEqual reports whether every element of x can be paired with a
+// distinct equal element of y. Exact equality is transitive, so elements are
+// paired greedily in order; with a FloatTolerance it is not, and a maximum
+// matching is searched with augmenting paths instead.
+func unorderedEqual(x, y []any, opts *EqualOptions) bool {
+	if opts.FloatTolerance > 0 {
+		return matchAll(x, y, opts)
+	}
+
+	used := make([]bool, len(y))
+outer:
+	for _, xv := range x {
+		for j, yv := range y {
+			if !used[j] && valuesEqual(xv, yv, opts) {
+				used[j] = true
+				continue outer
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// matchAll reports whether x and y, of the same length, have a perfect
+// matching of equal elements.
+func matchAll(x, y []any, opts *EqualOptions) bool {
+	equal := make([][]int, len(x)) // indexes in y equal to each element of x
+	for i, xv := range x {
+		for j, yv := range y {
+			if valuesEqual(xv, yv, opts) {
+				equal[i] = append(equal[i], j)
+			}
+		}
+		if len(equal[i]) == 0 {
+			return false
+		}
+	}
+
+	owner := make([]int, len(y)) // index in x paired with each element of y, or -1
+	for j := range owner {
+		owner[j] = -1
+	}
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for _, j := range equal[i] {
+			if seen[j] {
+				continue
+			}
+			seen[j] = true
+			if owner[j] < 0 || augment(owner[j], seen) {
+				owner[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	for i := range x {
+		if !augment(i, make([]bool, len(y))) {
+			return false
+		}
+	}
+	return true
+}
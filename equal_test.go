@@ -0,0 +1,89 @@
+package jester_test
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestEqual(t *testing.T) {
+	parsed, err := jester.NewJson([]byte(`{"a": 1, "b": [1.5, "x", true, null], "c": {"d": 255}}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	built := jester.New(map[string]any{
+		"a": uint8(1),
+		"b": []any{float32(1.5), "x", true, nil},
+		"c": map[string]any{"d": int64(255)},
+	})
+
+	if !jester.Equal(parsed, built) {
+		t.Errorf("expected parsed and built documents to be equal")
+	}
+
+	for _, n := range []any{json.Number("1"), int(1), float64(1), uint8(1), json.Number("1.0"), json.Number("1e0")} {
+		if !jester.Equal(jester.New(n), jester.New(1)) {
+			t.Errorf("%#v should equal 1", n)
+		}
+	}
+
+	notEqual := []struct {
+		a, b *jester.Data
+	}{
+		{jester.New(1), jester.New("1")},
+		{jester.New(1), jester.New(1.0000001)},
+		{jester.New([]any{1, 2}), jester.New([]any{2, 1})},
+		{jester.New(map[string]any{"a": 1}), jester.New(map[string]any{"a": 1, "b": 2})},
+		{jester.New(nil), parsed.Get("missing")},
+		{jester.New([]byte("hi")), jester.New("aGk=")},
+		{jester.New(false), jester.New(nil)},
+	}
+	for _, tc := range notEqual {
+		if jester.Equal(tc.a, tc.b) {
+			t.Errorf("%#v should not equal %#v", tc.a.Interface(), tc.b.Interface())
+		}
+	}
+
+	if !jester.Equal(parsed.Get("missing"), parsed.Get("other")) {
+		t.Errorf("missing values should be equal")
+	}
+}
+
+func TestEqualOpts(t *testing.T) {
+	opts := jester.EqualOptions{BytesAsBase64: true}
+	if !jester.EqualOpts(jester.New([]byte("hi")), jester.New("aGk="), opts) {
+		t.Errorf("bytes should equal their base64 encoding")
+	}
+	if !jester.EqualOpts(jester.New("aGk="), jester.New([]byte("hi")), opts) {
+		t.Errorf("base64 encoding should equal its bytes")
+	}
+
+	opts = jester.EqualOptions{FloatTolerance: 1e-6}
+	if !jester.EqualOpts(jester.New(0.1+0.2), jester.New(json.Number("0.3")), opts) {
+		t.Errorf("numbers within tolerance should be equal")
+	}
+	if jester.EqualOpts(jester.New(0.3), jester.New(0.31), opts) {
+		t.Errorf("numbers outside tolerance should differ")
+	}
+
+	opts = jester.EqualOptions{UnorderedArrays: true}
+	a := jester.New([]any{1, "x", []any{2, 3}, 1})
+	b := jester.New([]any{[]any{3, 2}, 1, 1, "x"})
+	if !jester.EqualOpts(a, b, opts) {
+		t.Errorf("arrays should be equal ignoring order")
+	}
+	if jester.EqualOpts(jester.New([]any{1, 1, 2}), jester.New([]any{1, 2, 2}), opts) {
+		t.Errorf("element counts must match")
+	}
+
+	// 1.05 matches both 1.0 and 1.1, so pairing it with the first would fail.
+	opts = jester.EqualOptions{UnorderedArrays: true, FloatTolerance: 0.06}
+	if !jester.EqualOpts(jester.New([]any{1.05, 1.0}), jester.New([]any{1.0, 1.1}), opts) {
+		t.Errorf("arrays within tolerance should be equal ignoring order")
+	}
+	if jester.EqualOpts(jester.New([]any{1.05, 1.05}), jester.New([]any{1.0, 1.2}), opts) {
+		t.Errorf("every element must be within tolerance of its pair")
+	}
+}
@@ -2,4 +2,4 @@ module github.com/lb-selfbot/go-jester
 
 go 1.24.0
 
-require github.com/goccy/go-json v0.10.5 // indirect
+require github.com/goccy/go-json v0.10.5
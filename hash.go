@@ -0,0 +1,195 @@
+package jester
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"strconv"
+)
+
+// Tags identifying the type of each value in the hashed stream.
+const (
+	hashMissing = 'm'
+	hashNull    = 'n'
+	hashFalse   = 'f'
+	hashTrue    = 't'
+	hashNumber  = 'd'
+	hashString  = 's'
+	hashBytes   = 'b'
+	hashArray   = 'a'
+	hashObject  = 'o'
+	hashOther   = '?'
+)
+
+// Hash writes a canonical encoding of the data to h without marshaling it to
+// JSON first. Values that are Equal produce the same stream: numbers are
+// normalized, so json.Number("1.0") and int(1) hash alike, and object members
+// are written in sorted key order. Every value is tagged with its type and
+// every string and container is prefixed with its length, so different trees
+// never produce the same stream.
+func (d *Data) Hash(h hash.Hash) {
+	w := hashWriter{h: h}
+	if d.missing {
+		w.tag(hashMissing)
+		return
+	}
+	w.value(d.data)
+}
+
+// Fingerprint returns the SHA-256 fingerprint of the data. It is the root of
+// a Merkle tree in which every object and array is hashed over the
+// fingerprints of its children, so the fingerprint of a subtree is the same
+// wherever it appears; see Fingerprints. Like Hash, Equal values have equal
+// fingerprints.
+func (d *Data) Fingerprint() [32]byte {
+	if d.missing {
+		return sha256.Sum256([]byte{hashMissing})
+	}
+	return fingerprint(d.data, nil, nil)
+}
+
+// Fingerprints returns the fingerprint of every value in the tree, keyed by
+// its JSON Pointer; the root is "". Comparing them with the fingerprints of
+// an earlier version of the document tells which parts changed, in a single
+// pass over the tree.
+func (d *Data) Fingerprints() map[string][32]byte {
+	out := make(map[string][32]byte)
+	if d.missing {
+		return out
+	}
+	fingerprint(d.data, Path{}, out)
+	return out
+}
+
+// fingerprint returns the Merkle fingerprint of v and, if out is not nil,
+// records the fingerprints of v and its descendants at their pointers.
+func fingerprint(v any, path Path, out map[string][32]byte) [32]byte {
+	v = hashable(v)
+	h := sha256.New()
+	w := hashWriter{h: h}
+	switch x := v.(type) {
+	case []any:
+		w.tag(hashArray)
+		w.uvarint(uint64(len(x)))
+		for i, e := range x {
+			sum := fingerprint(e, childPath(path, out, i), out)
+			h.Write(sum[:])
+		}
+	case map[string]any:
+		w.tag(hashObject)
+		w.uvarint(uint64(len(x)))
+		for _, k := range sortedKeys(x) {
+			w.string(k)
+			sum := fingerprint(x[k], childPath(path, out, k), out)
+			h.Write(sum[:])
+		}
+	default:
+		w.value(v)
+	}
+
+	var sum [32]byte
+	h.Sum(sum[:0])
+	if out != nil {
+		out[path.Pointer()] = sum
+	}
+	return sum
+}
+
+// childPath returns the path of a child, or nil when no fingerprints are recorded.
+func childPath(path Path, out map[string][32]byte, key any) Path {
+	if out == nil {
+		return nil
+	}
+	return path.child(key)
+}
+
+// hashable converts values of types other than those produced by NewJson into
+// their JSON tree representation. Values that cannot be marshaled are kept.
+func hashable(v any) any {
+	switch v.(type) {
+	case nil, bool, string, []byte, []any, map[string]any:
+		return v
+	}
+	if _, ok := toRat(v); ok {
+		return v
+	}
+	switch v.(type) {
+	case float32, float64:
+		return v // NaN and infinities have no JSON encoding
+	}
+	if decoded, err := jsonValue(v); err == nil {
+		return decoded
+	}
+	return v
+}
+
+type hashWriter struct {
+	h   hash.Hash
+	buf [binary.MaxVarintLen64]byte
+}
+
+func (w *hashWriter) tag(t byte) {
+	w.buf[0] = t
+	w.h.Write(w.buf[:1])
+}
+
+func (w *hashWriter) uvarint(n uint64) {
+	w.h.Write(binary.AppendUvarint(w.buf[:0], n))
+}
+
+func (w *hashWriter) string(s string) {
+	w.uvarint(uint64(len(s)))
+	w.h.Write([]byte(s))
+}
+
+func (w *hashWriter) value(v any) {
+	switch x := hashable(v).(type) {
+	case nil:
+		w.tag(hashNull)
+	case bool:
+		if x {
+			w.tag(hashTrue)
+		} else {
+			w.tag(hashFalse)
+		}
+	case string:
+		w.tag(hashString)
+		w.string(x)
+	case []byte:
+		w.tag(hashBytes)
+		w.uvarint(uint64(len(x)))
+		w.h.Write(x)
+	case []any:
+		w.tag(hashArray)
+		w.uvarint(uint64(len(x)))
+		for _, e := range x {
+			w.value(e)
+		}
+	case map[string]any:
+		w.tag(hashObject)
+		w.uvarint(uint64(len(x)))
+		for _, k := range sortedKeys(x) {
+			w.string(k)
+			w.value(x[k])
+		}
+	default:
+		if r, ok := toRat(x); ok {
+			w.tag(hashNumber)
+			w.string(r.RatString())
+			return
+		}
+		switch f := x.(type) {
+		case float32:
+			w.tag(hashNumber)
+			w.string(strconv.FormatFloat(float64(f), 'g', -1, 32)) // NaN or an infinity
+		case float64:
+			w.tag(hashNumber)
+			w.string(strconv.FormatFloat(f, 'g', -1, 64))
+		default:
+			// A value that cannot be marshaled.
+			w.tag(hashOther)
+			w.string(fmt.Sprintf("%T:%#v", x, x))
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package jester_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestHash(t *testing.T) {
+	a, err := jester.NewJson([]byte(`{"id": "1", "count": 1.0, "tags": ["a", "b"], "owner": null}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	b := jester.New(map[string]any{"owner": nil, "tags": []any{"a", "b"}, "count": 1, "id": "1"})
+
+	sum := func(d *jester.Data) [32]byte {
+		h := sha256.New()
+		d.Hash(h)
+		var s [32]byte
+		h.Sum(s[:0])
+		return s
+	}
+
+	if sum(a) != sum(b) {
+		t.Errorf("got different hashes for equal documents")
+	}
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Errorf("got different fingerprints for equal documents")
+	}
+
+	for _, other := range []*jester.Data{
+		jester.New(map[string]any{"owner": nil, "tags": []any{"b", "a"}, "count": 1, "id": "1"}),
+		jester.New(map[string]any{"owner": nil, "tags": []any{"a", "b"}, "count": 1, "id": 1}),
+		jester.New(map[string]any{"tags": []any{"a", "b"}, "count": 1, "id": "1"}),
+		jester.New(map[string]any{"owner": nil, "tags": []any{"ab"}, "count": 1, "id": "1"}),
+	} {
+		if sum(a) == sum(other) {
+			t.Errorf("got equal hashes for %#v", other.Interface())
+		}
+		if a.Fingerprint() == other.Fingerprint() {
+			t.Errorf("got equal fingerprints for %#v", other.Interface())
+		}
+	}
+
+	if jester.New(nil).Fingerprint() == a.Get("missing").Fingerprint() {
+		t.Errorf("got equal fingerprints for null and missing")
+	}
+	if jester.New(json.Number("2.50")).Fingerprint() != jester.New(2.5).Fingerprint() {
+		t.Errorf("got different fingerprints for equal numbers")
+	}
+}
+
+func TestFingerprints(t *testing.T) {
+	a, err := jester.NewJson([]byte(`{"guild": {"name": "g", "roles": [{"id": 1}, {"id": 2}]}, "user": {"id": 1}}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	b, err := jester.NewJson([]byte(`{"guild": {"name": "g", "roles": [{"id": 1}, {"id": 3}]}, "user": {"id": 1}}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	fa, fb := a.Fingerprints(), b.Fingerprints()
+	if len(fa) != 10 || len(fb) != 10 {
+		t.Fatalf("got %d and %d fingerprints", len(fa), len(fb))
+	}
+	if fa[""] != a.Fingerprint() {
+		t.Errorf("got root %x expected %x", fa[""], a.Fingerprint())
+	}
+	if fa["/guild/roles/0"] != a.Get("user").Fingerprint() {
+		t.Errorf("got different fingerprints for equal subtrees")
+	}
+
+	var changed []string
+	for ptr, sum := range fa {
+		if fb[ptr] != sum {
+			changed = append(changed, ptr)
+		}
+	}
+	if len(changed) != 5 {
+		t.Errorf("got %#v", changed)
+	}
+	for _, ptr := range []string{"", "/guild", "/guild/roles", "/guild/roles/1", "/guild/roles/1/id"} {
+		if fa[ptr] == fb[ptr] {
+			t.Errorf("got unchanged fingerprint at %q", ptr)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package jester
+
+import (
+	"iter"
+	"slices"
+)
+
+// Entries returns an iterator over the members of the underlying object in
+// map order. Non-object values yield nothing.
+func (d *Data) Entries() iter.Seq2[string, *Data] {
+	return func(yield func(string, *Data) bool) {
+		m, _ := d.data.(map[string]any)
+		for k, v := range m {
+			if !yield(k, d.child(v)) {
+				return
+			}
+		}
+	}
+}
+
+// SortedEntries is like Entries but yields the members in sorted key order.
+func (d *Data) SortedEntries() iter.Seq2[string, *Data] {
+	return func(yield func(string, *Data) bool) {
+		m, _ := d.data.(map[string]any)
+		for _, k := range sortedKeys(m) {
+			if !yield(k, d.child(m[k])) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys of the underlying object in map order.
+// Non-object values yield nothing.
+func (d *Data) Keys() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		m, _ := d.data.(map[string]any)
+		for k := range m {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// SortedKeys is like Keys but yields the keys in sorted order.
+func (d *Data) SortedKeys() iter.Seq[string] {
+	m, _ := d.data.(map[string]any)
+	return slices.Values(sortedKeys(m))
+}
+
+// Values returns an iterator over the elements of the underlying array, or the
+// member values of the underlying object in map order. Other values yield nothing.
+func (d *Data) Values() iter.Seq[*Data] {
+	return func(yield func(*Data) bool) {
+		switch v := d.data.(type) {
+		case []any:
+			for _, e := range v {
+				if !yield(d.child(e)) {
+					return
+				}
+			}
+		case map[string]any:
+			for _, e := range v {
+				if !yield(d.child(e)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Items returns an iterator over the indexes and elements of the underlying
+// array. Non-array values yield nothing.
+func (d *Data) Items() iter.Seq2[int, *Data] {
+	return func(yield func(int, *Data) bool) {
+		s, _ := d.data.([]any)
+		for i, e := range s {
+			if !yield(i, d.child(e)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package jester_test
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestEntries(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"c": 3, "a": 1, "b": 2}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	got := map[string]int{}
+	for k, v := range js.Entries() {
+		got[k] = v.MustInt()
+	}
+	if !reflect.DeepEqual(got, map[string]int{"a": 1, "b": 2, "c": 3}) {
+		t.Errorf("got %#v", got)
+	}
+
+	var keys []string
+	var vals []int
+	for k, v := range js.SortedEntries() {
+		keys = append(keys, k)
+		vals = append(vals, v.MustInt())
+	}
+	if !reflect.DeepEqual(keys, []string{"a", "b", "c"}) || !reflect.DeepEqual(vals, []int{1, 2, 3}) {
+		t.Errorf("got %#v %#v", keys, vals)
+	}
+
+	if k := slices.Collect(js.SortedKeys()); !reflect.DeepEqual(k, []string{"a", "b", "c"}) {
+		t.Errorf("got %#v", k)
+	}
+	if k := slices.Sorted(js.Keys()); !reflect.DeepEqual(k, []string{"a", "b", "c"}) {
+		t.Errorf("got %#v", k)
+	}
+
+	sum := 0
+	for v := range js.Iterator() {
+		sum += v.MustInt()
+	}
+	if sum != 6 {
+		t.Errorf("object iteration: got sum %#v", sum)
+	}
+
+	for range js.Items() {
+		t.Errorf("Items on an object should yield nothing")
+	}
+	for range js.Get("a").Entries() {
+		t.Errorf("Entries on a number should yield nothing")
+	}
+}
+
+func TestItems(t *testing.T) {
+	js, err := jester.NewJson([]byte(`["a", "b", "c"]`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	var idx []int
+	var vals []string
+	for i, v := range js.Items() {
+		idx = append(idx, i)
+		vals = append(vals, v.MustString())
+	}
+	if !reflect.DeepEqual(idx, []int{0, 1, 2}) || !reflect.DeepEqual(vals, []string{"a", "b", "c"}) {
+		t.Errorf("got %#v %#v", idx, vals)
+	}
+
+	vals = vals[:0]
+	for v := range js.Values() {
+		vals = append(vals, v.MustString())
+		if len(vals) == 2 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(vals, []string{"a", "b"}) {
+		t.Errorf("got %#v", vals)
+	}
+
+	for range js.Keys() {
+		t.Errorf("Keys on an array should yield nothing")
+	}
+}
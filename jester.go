@@ -15,6 +15,12 @@ var ErrTypeMismatch = errors.New("jester: type assertion failed (type mismatch)"
 
 type Data struct {
 	data any
+	// missing is set when the value was looked up but does not exist,
+	// as opposed to existing with an explicit JSON null.
+	missing bool
+	// lenient makes numeric accessors accept numbers encoded as strings,
+	// see Lenient. It is inherited by values retrieved from this one.
+	lenient bool
 }
 
 // MarshalJSON implements the json.Marshaler interface.
@@ -73,81 +79,11 @@ func (d *Data) Set(key string, val any) {
 }
 
 // SetPath modifies the data structure by setting the value for the specified path.
+// Missing values, nulls and scalars along the path are replaced by objects, but
+// nothing is set if the data itself is not an object or array; use SetPathE to
+// find out why a path could not be set.
 func (d *Data) SetPath(branch []any, val any) {
-	if len(branch) == 0 {
-		d.data = val
-		return
-	}
-
-	current := d
-
-	for i := range len(branch) - 1 {
-		key := branch[i]
-
-		next := current.get(key)
-		if next.data == nil {
-			switch k := key.(type) {
-			case string:
-				current.Set(k, make(map[string]any))
-			case int:
-				// Need to create a slice large enough to hold the index
-				slice, err := current.Slice()
-				if err != nil || int(k) >= len(slice) {
-					current.Set(strconv.Itoa(k), make(map[string]any))
-				} else {
-					// Ensure slice has capacity
-					index := k
-					for len(slice) <= index {
-						slice = append(slice, nil)
-					}
-					slice[index] = make(map[string]any)
-					current.data = slice
-				}
-			}
-			current = current.get(key)
-			continue
-		}
-
-		// If next data is not a map or slice, convert it to a map
-		if _, errMap := next.Map(); errMap != nil {
-			if _, errSlice := next.Slice(); errSlice != nil {
-				// Force convert primitive to map
-				switch k := key.(type) {
-				case string:
-					current.Set(k, make(map[string]any))
-				case int:
-					current.Set(strconv.Itoa(k), make(map[string]any))
-				}
-			}
-		}
-
-		current = current.get(key)
-	}
-
-	lastKey := branch[len(branch)-1]
-
-	if k, ok := lastKey.(string); ok {
-		current.Set(k, val)
-		return
-	}
-
-	k, ok := lastKey.(int)
-	if !ok {
-		return
-	}
-
-	slice, err := current.Slice()
-	if err != nil {
-		// Not a slice, convert to map and use string key
-		current.Set(strconv.Itoa(k), val)
-		return
-	}
-
-	for len(slice) <= k {
-		slice = append(slice, nil)
-	}
-	slice[k] = val
-	current.data = slice
+	_ = d.SetPathE(branch, val)
 }
 
 // Delete deletes a key from the data structure.
@@ -160,39 +96,79 @@ func (d *Data) Delete(key string) {
 }
 
 // Get retrieves a value from the data structure at the specified path.
+// Keys are strings or integers of any type; a Path can be passed as Get(path...).
+// If the path does not exist, the returned Data reports false from Exists.
 func (d *Data) Get(keys ...any) *Data {
+	data, _ := d.Lookup(keys...)
+	return data
+}
+
+// Lookup retrieves a value from the data structure at the specified path and
+// reports whether it exists. An explicit JSON null exists.
+func (d *Data) Lookup(keys ...any) (*Data, bool) {
 	data := d
 
 	for _, key := range keys {
 		data = data.get(key)
 
-		// If the data is nil, return it immediately.
-		if data.data == nil {
-			return data
+		// If the key is missing, nothing further down the path can exist.
+		if data.missing {
+			return data, false
 		}
 	}
 
-	return data
+	return data, !data.missing
+}
+
+// Has reports whether a value exists at the specified path.
+func (d *Data) Has(keys ...any) bool {
+	_, ok := d.Lookup(keys...)
+	return ok
+}
+
+// Exists reports whether the value exists. It is false for values returned by
+// Get for a path that is not present in the data structure.
+func (d *Data) Exists() bool {
+	return !d.missing
+}
+
+// IsNull reports whether the value exists and is an explicit JSON null.
+func (d *Data) IsNull() bool {
+	return !d.missing && d.data == nil
+}
+
+func missing() *Data {
+	return &Data{missing: true}
+}
+
+// child wraps a value retrieved from d, inheriting its options.
+func (d *Data) child(v any) *Data {
+	return &Data{data: v, lenient: d.lenient}
 }
 
 func (d *Data) get(key any) *Data {
 	if d.data == nil {
-		return New(nil)
+		return missing()
+	}
+
+	key, ok := normalizeKey(key)
+	if !ok {
+		return missing()
 	}
 
 	// Try as map with string key
 	if dataMap, ok := d.data.(map[string]any); ok {
 		if keyStr, ok := key.(string); ok {
 			if v, ok := dataMap[keyStr]; ok {
-				return New(v)
+				return d.child(v)
 			}
-			return New(nil)
+			return missing()
 		}
 		// Try to convert int key to string for maps
 		if keyInt, ok := key.(int); ok {
 			keyStr := strconv.Itoa(keyInt)
 			if v, ok := dataMap[keyStr]; ok {
-				return New(v)
+				return d.child(v)
 			}
 		}
 	}
@@ -201,15 +177,15 @@ func (d *Data) get(key any) *Data {
 	if dataSlice, ok := d.data.([]any); ok {
 		if keyInt, ok := key.(int); ok {
 			if keyInt >= 0 && keyInt < len(dataSlice) {
-				return New(dataSlice[keyInt])
+				return d.child(dataSlice[keyInt])
 			}
 		}
 	}
 
-	return New(nil)
+	return missing()
 }
 
-// Len returns the length of the underlying data.
+// Len returns the length of the underlying data. Missing and null values have a length of 0.
 func (d *Data) Len() int {
 	if d.data == nil {
 		return 0
@@ -277,15 +253,11 @@ func (d *Data) MustSlice(args ...[]any) []any {
 	return value
 }
 
-// Iterator returns an iterator for the underlying data.
+// Iterator returns an iterator over the elements of the underlying array or
+// the member values of the underlying object, see Values. Null elements are
+// yielded as existing null values; missing, null and scalar values yield nothing.
 func (d *Data) Iterator() iter.Seq[*Data] {
-	return func(yield func(*Data) bool) {
-		for i := range d.Len() {
-			if !yield(d.Get(i)) {
-				return
-			}
-		}
-	}
+	return d.Values()
 }
 
 // Bool returns the underlying data as a bool.
@@ -390,18 +362,19 @@ func (d *Data) MustStringSlice(args ...[]string) []string {
 	return value
 }
 
-// Int returns the underlying data as an int.
+// Int returns the underlying data as an int. Floats are truncated; use
+// IntStrict to reject fractional and out of range values.
 func (d *Data) Int() (int, error) {
-	switch v := d.data.(type) {
+	switch v := d.number().(type) {
 	case json.Number:
 		i, err := v.Int64()
 		return int(i), err
 	case int, int8, int16, int32, int64:
-		return int(reflect.ValueOf(d.data).Int()), nil
+		return int(reflect.ValueOf(v).Int()), nil
 	case uint, uint8, uint16, uint32, uint64:
-		return int(reflect.ValueOf(d.data).Uint()), nil
+		return int(reflect.ValueOf(v).Uint()), nil
 	case float32, float64:
-		return int(reflect.ValueOf(d.data).Float()), nil
+		return int(reflect.ValueOf(v).Float()), nil
 	default:
 		return 0, ErrTypeMismatch
 	}
@@ -420,17 +393,18 @@ func (d *Data) MustInt(args ...int) int {
 	return value
 }
 
-// Int64 returns the underlying data as an int64.
+// Int64 returns the underlying data as an int64. Floats are truncated; use
+// Int64Strict to reject fractional and out of range values.
 func (d *Data) Int64() (int64, error) {
-	switch v := d.data.(type) {
+	switch v := d.number().(type) {
 	case json.Number:
 		return v.Int64()
 	case int, int8, int16, int32, int64:
-		return reflect.ValueOf(d.data).Int(), nil
+		return reflect.ValueOf(v).Int(), nil
 	case uint, uint8, uint16, uint32, uint64:
-		return int64(reflect.ValueOf(d.data).Uint()), nil
+		return int64(reflect.ValueOf(v).Uint()), nil
 	case float32, float64:
-		return int64(reflect.ValueOf(d.data).Float()), nil
+		return int64(reflect.ValueOf(v).Float()), nil
 	default:
 		return 0, ErrTypeMismatch
 	}
@@ -449,17 +423,18 @@ func (d *Data) MustInt64(args ...int64) int64 {
 	return value
 }
 
-// Uint64 returns the underlying data as a uint64.
+// Uint64 returns the underlying data as a uint64. Floats are truncated and
+// negative values wrap around; use Uint64Strict to reject them.
 func (d *Data) Uint64() (uint64, error) {
-	switch v := d.data.(type) {
+	switch v := d.number().(type) {
 	case json.Number:
 		return strconv.ParseUint(v.String(), 10, 64)
 	case int, int8, int16, int32, int64:
-		return uint64(reflect.ValueOf(d.data).Int()), nil
+		return uint64(reflect.ValueOf(v).Int()), nil
 	case uint, uint8, uint16, uint32, uint64:
-		return reflect.ValueOf(d.data).Uint(), nil
+		return reflect.ValueOf(v).Uint(), nil
 	case float32, float64:
-		return uint64(reflect.ValueOf(d.data).Float()), nil
+		return uint64(reflect.ValueOf(v).Float()), nil
 	default:
 		return 0, ErrTypeMismatch
 	}
@@ -480,15 +455,15 @@ func (d *Data) MustUint64(args ...uint64) uint64 {
 
 // Float64 returns the underlying data as a float64.
 func (d *Data) Float64() (float64, error) {
-	switch v := d.data.(type) {
+	switch v := d.number().(type) {
 	case json.Number:
 		return v.Float64()
 	case int, int8, int16, int32, int64:
-		return float64(reflect.ValueOf(d.data).Int()), nil
+		return float64(reflect.ValueOf(v).Int()), nil
 	case uint, uint8, uint16, uint32, uint64:
-		return float64(reflect.ValueOf(d.data).Uint()), nil
+		return float64(reflect.ValueOf(v).Uint()), nil
 	case float32, float64:
-		return reflect.ValueOf(d.data).Float(), nil
+		return reflect.ValueOf(v).Float(), nil
 	default:
 		return 0, ErrTypeMismatch
 	}
@@ -472,3 +472,68 @@ func TestIterator(t *testing.T) {
 		t.Errorf("nested object iteration: got %#v, expected %#v", items, expectedItems)
 	}
 }
+
+func TestLookup(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"a": {"b": null, "c": 1}, "list": [null, 2]}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	cases := []struct {
+		path   []any
+		exists bool
+		null   bool
+	}{
+		{[]any{"a"}, true, false},
+		{[]any{"a", "b"}, true, true},
+		{[]any{"a", "c"}, true, false},
+		{[]any{"a", "d"}, false, false},
+		{[]any{"a", "b", "x"}, false, false},
+		{[]any{"list", 0}, true, true},
+		{[]any{"list", 1}, true, false},
+		{[]any{"list", 2}, false, false},
+		{[]any{"missing", "deeper"}, false, false},
+		{[]any{}, true, false},
+	}
+
+	for _, tc := range cases {
+		v, ok := js.Lookup(tc.path...)
+		if ok != tc.exists {
+			t.Errorf("%#v: got exists %#v expected %#v", tc.path, ok, tc.exists)
+		}
+		if has := js.Has(tc.path...); has != tc.exists {
+			t.Errorf("%#v: got has %#v expected %#v", tc.path, has, tc.exists)
+		}
+		if v.Exists() != tc.exists {
+			t.Errorf("%#v: got Exists() %#v expected %#v", tc.path, v.Exists(), tc.exists)
+		}
+		if got := js.Get(tc.path...); got.IsNull() != tc.null {
+			t.Errorf("%#v: got IsNull() %#v expected %#v", tc.path, got.IsNull(), tc.null)
+		}
+	}
+
+	if !jester.New(nil).IsNull() {
+		t.Errorf("New(nil) should be null")
+	}
+
+	missing := js.Get("missing")
+	if l := missing.Len(); l != 0 {
+		t.Errorf("got %#v", l)
+	}
+	for range missing.Iterator() {
+		t.Errorf("missing value should not yield")
+	}
+
+	var nulls int
+	for v := range js.Get("list").Iterator() {
+		if !v.Exists() {
+			t.Errorf("array element should exist")
+		}
+		if v.IsNull() {
+			nulls++
+		}
+	}
+	if nulls != 1 {
+		t.Errorf("got %#v nulls", nulls)
+	}
+}
@@ -0,0 +1,100 @@
+package jester
+
+import "github.com/goccy/go-json"
+
+// Kind is the JSON type of a Data value.
+type Kind int
+
+const (
+	// KindMissing is the kind of a value that does not exist, see Data.Exists.
+	KindMissing Kind = iota
+	KindNull
+	KindBool
+	KindNumber
+	KindString
+	KindArray
+	KindObject
+	KindBytes
+	// KindOther is the kind of any value that is not a JSON type handled by
+	// the accessors, such as a []string or a struct passed to New.
+	KindOther
+)
+
+var kindNames = [...]string{
+	KindMissing: "missing",
+	KindNull:    "null",
+	KindBool:    "bool",
+	KindNumber:  "number",
+	KindString:  "string",
+	KindArray:   "array",
+	KindObject:  "object",
+	KindBytes:   "bytes",
+	KindOther:   "other",
+}
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	if k >= 0 && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "unknown"
+}
+
+// Kind returns the JSON type of the underlying data.
+func (d *Data) Kind() Kind {
+	if d.missing {
+		return KindMissing
+	}
+
+	switch d.data.(type) {
+	case nil:
+		return KindNull
+	case bool:
+		return KindBool
+	case json.Number,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return KindNumber
+	case string:
+		return KindString
+	case []any:
+		return KindArray
+	case map[string]any:
+		return KindObject
+	case []byte:
+		return KindBytes
+	}
+
+	return KindOther
+}
+
+// IsBool reports whether the underlying data is a bool.
+func (d *Data) IsBool() bool {
+	return d.Kind() == KindBool
+}
+
+// IsNumber reports whether the underlying data is a number of any representation.
+func (d *Data) IsNumber() bool {
+	return d.Kind() == KindNumber
+}
+
+// IsString reports whether the underlying data is a string.
+func (d *Data) IsString() bool {
+	return d.Kind() == KindString
+}
+
+// IsArray reports whether the underlying data is a []any.
+func (d *Data) IsArray() bool {
+	return d.Kind() == KindArray
+}
+
+// IsObject reports whether the underlying data is a map[string]any.
+func (d *Data) IsObject() bool {
+	return d.Kind() == KindObject
+}
+
+// IsBytes reports whether the underlying data is a []byte.
+func (d *Data) IsBytes() bool {
+	return d.Kind() == KindBytes
+}
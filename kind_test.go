@@ -0,0 +1,50 @@
+package jester_test
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestKind(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"n": null, "b": true, "i": 1, "f": 1.5, "s": "x", "a": [], "o": {}}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	cases := []struct {
+		data *jester.Data
+		kind jester.Kind
+	}{
+		{js.Get("n"), jester.KindNull},
+		{js.Get("b"), jester.KindBool},
+		{js.Get("i"), jester.KindNumber},
+		{js.Get("f"), jester.KindNumber},
+		{js.Get("s"), jester.KindString},
+		{js.Get("a"), jester.KindArray},
+		{js.Get("o"), jester.KindObject},
+		{js.Get("missing"), jester.KindMissing},
+		{jester.New(uint8(1)), jester.KindNumber},
+		{jester.New(json.Number("1")), jester.KindNumber},
+		{jester.New([]byte("x")), jester.KindBytes},
+		{jester.New([]string{"x"}), jester.KindOther},
+		{jester.New(nil), jester.KindNull},
+	}
+
+	for _, tc := range cases {
+		if k := tc.data.Kind(); k != tc.kind {
+			t.Errorf("%#v: got %v expected %v", tc.data.Interface(), k, tc.kind)
+		}
+	}
+
+	if !js.IsObject() || js.IsArray() {
+		t.Errorf("expected object")
+	}
+	if !js.Get("a").IsArray() || !js.Get("i").IsNumber() || !js.Get("s").IsString() || !js.Get("b").IsBool() {
+		t.Errorf("kind predicates disagree with Kind()")
+	}
+	if s := jester.KindObject.String(); s != "object" {
+		t.Errorf("got %#v", s)
+	}
+}
@@ -0,0 +1,60 @@
+package jester
+
+import (
+	"strconv"
+
+	"github.com/goccy/go-json"
+)
+
+// Lenient returns a view of the data in which the numeric accessors Int,
+// Int64, Uint64, Float64, their Must and Strict forms, the typed slices and
+// As also accept numbers encoded as JSON strings, such as the "1234" IDs of
+// APIs that send 64-bit integers as strings. Values retrieved from the view
+// are lenient as well. The view shares the underlying data with d.
+//
+// Only strings holding a valid JSON number are coerced; other strings still
+// fail with ErrTypeMismatch.
+func (d *Data) Lenient() *Data {
+	c := *d
+	c.lenient = true
+	return &c
+}
+
+// IsLenient reports whether the data is a lenient view, see Lenient.
+func (d *Data) IsLenient() bool {
+	return d.lenient
+}
+
+// SetPathInt64String sets the value for the specified path like SetPathE,
+// storing n as a decimal string.
+func (d *Data) SetPathInt64String(branch []any, n int64) error {
+	return d.SetPathE(branch, strconv.FormatInt(n, 10))
+}
+
+// SetPathUint64String sets the value for the specified path like SetPathE,
+// storing n as a decimal string.
+func (d *Data) SetPathUint64String(branch []any, n uint64) error {
+	return d.SetPathE(branch, strconv.FormatUint(n, 10))
+}
+
+// number returns the underlying data for the numeric accessors, with numeric
+// strings converted to json.Number if the data is lenient.
+func (d *Data) number() any {
+	if d.lenient {
+		return lenientNumber(d.data)
+	}
+	return d.data
+}
+
+// lenientNumber converts a string holding a valid JSON number to a json.Number.
+func lenientNumber(v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	p := jpParser{src: s}
+	if _, err := p.parseNumber(); err != nil || !p.eof() {
+		return v
+	}
+	return json.Number(s)
+}
@@ -0,0 +1,130 @@
+package jester_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestLenient(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"guild": {"id": "1234567890123456789", "owner_id": "-5", "ratio": "0.5",
+		"roles": ["1", "2", null], "name": "g", "hex": "0x10"}}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	if _, err := js.Get("guild", "id").Uint64(); err == nil {
+		t.Errorf("expected an error without Lenient")
+	}
+
+	l := js.Lenient()
+	if !l.IsLenient() || js.IsLenient() {
+		t.Errorf("got lenient flags %v and %v", l.IsLenient(), js.IsLenient())
+	}
+	guild := l.Get("guild")
+
+	if v, err := guild.Get("id").Uint64(); err != nil || v != 1234567890123456789 {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := guild.Get("owner_id").Int64(); err != nil || v != -5 {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v := guild.Get("ratio").MustFloat64(); v != 0.5 {
+		t.Errorf("got %#v", v)
+	}
+	if v, err := guild.Get("roles").Uint64Slice(); err != nil || !reflect.DeepEqual(v, []uint64{1, 2, 0}) {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := jester.GetAs[[]int64](guild, "roles"); err != nil || !reflect.DeepEqual(v, []int64{1, 2, 0}) {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if _, err := guild.Get("owner_id").Uint64Strict(); !errors.Is(err, jester.ErrOutOfRange) {
+		t.Errorf("err %#v", err)
+	}
+	for _, key := range []string{"name", "hex"} {
+		if _, err := guild.Get(key).Int64(); !errors.Is(err, jester.ErrTypeMismatch) {
+			t.Errorf("%s: err %#v", key, err)
+		}
+	}
+
+	// Strings are still strings.
+	if v := guild.Get("id").MustString(); v != "1234567890123456789" {
+		t.Errorf("got %#v", v)
+	}
+
+	for path, node := range l.All() {
+		if !node.IsLenient() {
+			t.Errorf("got non-lenient node at %s", path)
+		}
+	}
+}
+
+func TestSetPathUint64String(t *testing.T) {
+	js := jester.NewEmpty()
+	if err := js.SetPathUint64String([]any{"guild", "id"}, 18446744073709551615); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if err := js.SetPathInt64String([]any{"guild", "owner_id"}, -5); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	assertJSON(t, js, `{"guild": {"id": "18446744073709551615", "owner_id": "-5"}}`)
+	if v := js.Lenient().Get("guild", "id").MustUint64(); v != 18446744073709551615 {
+		t.Errorf("got %#v", v)
+	}
+
+	if err := js.SetPathInt64String([]any{"guild", 1.5}, 1); !errors.Is(err, jester.ErrInvalidPath) {
+		t.Errorf("got %#v", err)
+	}
+}
+
+func TestLenientResults(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"id": "123", "roles": [{"id": "4"}]}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	l := js.Lenient()
+
+	d, err := l.GetPointer("/id")
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if v, err := d.Int64(); err != nil || v != 123 {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+
+	res, err := l.Query("$.id")
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if v, err := res[0].Int64(); err != nil || v != 123 {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+
+	matches, err := l.QueryMatches("$.roles[*].id")
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if v, err := matches[0].Value.Uint64(); err != nil || v != 4 {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+
+	other := jester.New(map[string]any{"id": "124", "roles": []any{map[string]any{"id": "5"}}})
+	changes := jester.Compare(l, other)
+	if len(changes) != 2 {
+		t.Fatalf("got %#v", changes)
+	}
+	if v, err := changes[0].Old.Int64(); err != nil || v != 123 {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+
+	merged, conflicts := jester.Merge3(js, l, other)
+	if len(conflicts) != 0 {
+		t.Fatalf("got %#v", conflicts)
+	}
+	if v, err := merged.Get("id").Int64(); err != nil || v != 124 {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+}
@@ -0,0 +1,232 @@
+package jester
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var ErrMergeConflict = errors.New("jester: merge conflict")
+
+// ArrayStrategy selects how Merge combines two arrays found at the same path.
+type ArrayStrategy int
+
+const (
+	// ArrayReplace replaces the receiver's array with the other array.
+	ArrayReplace ArrayStrategy = iota
+	// ArrayAppend appends the other array's elements to the receiver's array.
+	ArrayAppend
+	// ArrayMergeIndex merges elements at the same index and appends any extra elements.
+	ArrayMergeIndex
+	// ArrayMergeKey merges object elements whose MergeOptions.KeyField values are equal
+	// and appends elements that have no counterpart.
+	ArrayMergeKey
+)
+
+// ConflictPolicy selects what Merge does when both sides hold different values
+// at the same path and the values cannot be merged recursively. A null on
+// either side is a value like any other, while members and elements missing
+// from the receiver are simply added. Two arrays are never a conflict; they
+// are combined according to the ArrayStrategy.
+type ConflictPolicy int
+
+const (
+	// ConflictOverwrite takes the other value.
+	ConflictOverwrite ConflictPolicy = iota
+	// ConflictKeep keeps the receiver's value.
+	ConflictKeep
+	// ConflictError makes Merge fail with ErrMergeConflict.
+	ConflictError
+)
+
+// MergeOptions configures Merge.
+type MergeOptions struct {
+	Arrays ArrayStrategy
+	// KeyField is the object member identifying array elements for ArrayMergeKey.
+	KeyField string
+	// Conflicts also applies to a null in the other document replacing a
+	// non-null value, unless NullDeletes is set.
+	Conflicts ConflictPolicy
+	// NullDeletes removes members whose value in the other document is null,
+	// as a JSON Merge Patch does.
+	NullDeletes bool
+}
+
+// MergePatch applies an RFC 7396 JSON Merge Patch to the data: null members
+// delete, objects are merged recursively and everything else is replaced.
+func (d *Data) MergePatch(patch *Data) {
+	d.data = mergePatch(d.data, patch.data)
+}
+
+func mergePatch(target, patch any) any {
+	pm, ok := patch.(map[string]any)
+	if !ok {
+		return deepCopy(patch)
+	}
+
+	tm, ok := target.(map[string]any)
+	if !ok {
+		tm = make(map[string]any, len(pm))
+	}
+	for k, v := range pm {
+		if v == nil {
+			delete(tm, k)
+			continue
+		}
+		tm[k] = mergePatch(tm[k], v)
+	}
+	return tm
+}
+
+// Merge deep merges other into the data. Objects are always merged member by
+// member; arrays and conflicting values are handled according to opts.
+// Values taken from other are copied, and on error the data is left unchanged.
+func (d *Data) Merge(other *Data, opts MergeOptions) error {
+	if d.missing {
+		d.data, d.missing = deepCopy(other.data), false
+		return nil
+	}
+
+	merged, err := mergeValues(nil, d.data, other.data, &opts)
+	if err != nil {
+		return err
+	}
+	d.data = merged
+	return nil
+}
+
+func mergeValues(path []string, a, b any, opts *MergeOptions) (any, error) {
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+
+		m := make(map[string]any, len(x)+len(y))
+		for k, v := range x {
+			m[k] = v
+		}
+		for k, v := range y {
+			if v == nil && opts.NullDeletes {
+				delete(m, k)
+				continue
+			}
+			cur, ok := m[k]
+			if !ok {
+				m[k] = deepCopy(v)
+				continue
+			}
+			merged, err := mergeValues(appendToken(path, k), cur, v, opts)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = merged
+		}
+		return m, nil
+	case []any:
+		y, ok := b.([]any)
+		if !ok {
+			break
+		}
+		if opts.Arrays == ArrayReplace {
+			// Replacing is the strategy, not a conflict.
+			return deepCopy(y), nil
+		}
+		return mergeArrays(path, x, y, opts)
+	}
+
+	if jsonEqual(a, b) {
+		return a, nil
+	}
+
+	switch opts.Conflicts {
+	case ConflictKeep:
+		return a, nil
+	case ConflictError:
+		return nil, pointerError(ErrMergeConflict, path)
+	}
+	return deepCopy(b), nil
+}
+
+func mergeArrays(path []string, x, y []any, opts *MergeOptions) (any, error) {
+	switch opts.Arrays {
+	case ArrayAppend:
+		s := make([]any, 0, len(x)+len(y))
+		s = append(s, x...)
+		for _, v := range y {
+			s = append(s, deepCopy(v))
+		}
+		return s, nil
+	case ArrayMergeIndex:
+		s := make([]any, max(len(x), len(y)))
+		copy(s, x)
+		for i, v := range y {
+			if i >= len(x) {
+				s[i] = deepCopy(v)
+				continue
+			}
+			merged, err := mergeValues(appendToken(path, strconv.Itoa(i)), s[i], v, opts)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = merged
+		}
+		return s, nil
+	case ArrayMergeKey:
+		s := make([]any, len(x), len(x)+len(y))
+		copy(s, x)
+
+		index := make(map[string]int, len(x))
+		for i, v := range x {
+			if k, ok := elementKey(v, opts.KeyField); ok {
+				index[k] = i
+			}
+		}
+		for _, v := range y {
+			k, ok := elementKey(v, opts.KeyField)
+			i, found := index[k]
+			if !ok || !found {
+				s = append(s, deepCopy(v))
+				continue
+			}
+			merged, err := mergeValues(appendToken(path, strconv.Itoa(i)), s[i], v, opts)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = merged
+		}
+		return s, nil
+	}
+	return deepCopy(y), nil
+}
+
+// elementKey returns the identity of an array element: the value of its
+// field member, normalized so that equal JSON values yield equal keys.
+func elementKey(v any, field string) (string, bool) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return "", false
+	}
+	id, ok := m[field]
+	if !ok {
+		return "", false
+	}
+	return identityKey(id)
+}
+
+// identityKey normalizes a scalar value into a string usable as a map key.
+func identityKey(v any) (string, bool) {
+	switch x := v.(type) {
+	case string:
+		return "s" + x, true
+	case bool:
+		return fmt.Sprint("b", x), true
+	case nil:
+		return "null", true
+	}
+	if r, ok := toRat(v); ok {
+		return "n" + r.RatString(), true
+	}
+	return "", false
+}
@@ -0,0 +1,83 @@
+package jester
+
+// Conflict is a path at which both sides of a three-way merge changed the
+// base value in different ways.
+type Conflict struct {
+	Path Path
+	// Base, Ours and Theirs are the values at Path in each document; any of
+	// them may not exist, e.g. when one side deleted the value.
+	Base, Ours, Theirs *Data
+}
+
+// Resolver decides a Conflict. It returns the merged value and true, or false
+// to leave the conflict unresolved. Returning nil or a value that does not
+// exist, such as c.Theirs after a deletion, removes the value from the result.
+type Resolver func(c Conflict) (*Data, bool)
+
+// Merge3 merges the concurrent edits ours and theirs made to base. A change
+// made on only one side is taken; objects changed on both sides are merged
+// member by member. Anything else changed differently on both sides, including
+// arrays, is a conflict: the result keeps our value and the conflict is
+// reported. The inputs are not modified and the result shares no containers
+// with them.
+func Merge3(base, ours, theirs *Data) (*Data, []Conflict) {
+	return Merge3With(base, ours, theirs, nil)
+}
+
+// Merge3With is like Merge3 but passes every conflict to resolve first. Only
+// the conflicts it leaves unresolved are returned.
+func Merge3With(base, ours, theirs *Data, resolve Resolver) (*Data, []Conflict) {
+	m := merger3{resolve: resolve}
+	res := m.merge(Path{}, base, ours, theirs)
+	// The result has the options of ours. If both sides deleted the
+	// document, or the resolver did, it is null.
+	return ours.child(res.data), m.conflicts
+}
+
+type merger3 struct {
+	resolve   Resolver
+	conflicts []Conflict
+}
+
+func (m *merger3) merge(path Path, base, ours, theirs *Data) *Data {
+	if ours.IsObject() && theirs.IsObject() {
+		// Merging member by member gives the same result as taking a side
+		// when the objects are unchanged or changed alike, without comparing
+		// whole subtrees at every level. A base that is not an object merges
+		// like an empty one.
+		keys := make(map[string]any, ours.Len()+theirs.Len())
+		for k := range ours.MustMap() {
+			keys[k] = nil
+		}
+		for k := range theirs.MustMap() {
+			keys[k] = nil
+		}
+
+		merged := make(map[string]any, len(keys))
+		for _, k := range sortedKeys(keys) {
+			if v := m.merge(path.child(k), base.get(k), ours.get(k), theirs.get(k)); v.Exists() {
+				merged[k] = v.data
+			}
+		}
+		return ours.child(merged)
+	}
+
+	switch {
+	case Equal(ours, theirs), Equal(base, theirs):
+		return ours.Clone()
+	case Equal(base, ours):
+		return theirs.Clone()
+	}
+
+	c := Conflict{Path: path, Base: base, Ours: ours, Theirs: theirs}
+	if m.resolve != nil {
+		if v, ok := m.resolve(c); ok {
+			if v == nil {
+				return missing()
+			}
+			return v.Clone()
+		}
+	}
+	m.conflicts = append(m.conflicts, c)
+	return ours.Clone()
+}
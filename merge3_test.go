@@ -0,0 +1,102 @@
+package jester_test
+
+import (
+	"testing"
+
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestMerge3(t *testing.T) {
+	base, err := jester.NewJson([]byte(`{"theme": "dark", "locale": "en", "status": "online", "flags": {"a": 1, "b": 2}, "list": [1]}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	ours, err := jester.NewJson([]byte(`{"theme": "light", "locale": "en", "status": "idle", "flags": {"a": 1, "b": 3}, "list": [1, 2]}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	theirs, err := jester.NewJson([]byte(`{"theme": "dark", "locale": "fr", "status": "dnd", "flags": {"b": 2, "c": 4}, "list": [0, 1], "new": true}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	merged, conflicts := jester.Merge3(base, ours, theirs)
+	assertJSON(t, merged, `{"theme": "light", "locale": "fr", "status": "idle", "flags": {"b": 3, "c": 4}, "list": [1, 2], "new": true}`)
+
+	if len(conflicts) != 2 {
+		t.Fatalf("got %#v", conflicts)
+	}
+	if p := conflicts[0].Path.String(); p != "list" {
+		t.Errorf("got %#v", p)
+	}
+	if p := conflicts[1].Path.String(); p != "status" {
+		t.Errorf("got %#v", p)
+	}
+	c := conflicts[1]
+	if c.Base.MustString() != "online" || c.Ours.MustString() != "idle" || c.Theirs.MustString() != "dnd" {
+		t.Errorf("got %#v", c)
+	}
+
+	// The inputs are left untouched.
+	assertJSON(t, ours, `{"theme": "light", "locale": "en", "status": "idle", "flags": {"a": 1, "b": 3}, "list": [1, 2]}`)
+}
+
+func TestMerge3Delete(t *testing.T) {
+	base := jester.New(map[string]any{"a": 1, "b": 1})
+	ours := jester.New(map[string]any{"b": 1})
+	theirs := jester.New(map[string]any{"a": 1, "b": 2})
+
+	merged, conflicts := jester.Merge3(base, ours, theirs)
+	if len(conflicts) != 0 {
+		t.Fatalf("got %#v", conflicts)
+	}
+	assertJSON(t, merged, `{"b": 2}`)
+
+	// Deleting on one side and modifying on the other conflicts.
+	theirs = jester.New(map[string]any{"a": 2, "b": 1})
+	merged, conflicts = jester.Merge3(base, ours, theirs)
+	if len(conflicts) != 1 || conflicts[0].Ours.Exists() || conflicts[0].Theirs.MustInt() != 2 {
+		t.Fatalf("got %#v", conflicts)
+	}
+	assertJSON(t, merged, `{"b": 1}`)
+}
+
+func TestMerge3With(t *testing.T) {
+	base := jester.New(map[string]any{"a": 1, "b": 1, "c": 1})
+	ours := jester.New(map[string]any{"a": 2, "b": 2})
+	theirs := jester.New(map[string]any{"a": 3, "b": 3, "c": 2})
+
+	merged, conflicts := jester.Merge3With(base, ours, theirs, func(c jester.Conflict) (*jester.Data, bool) {
+		switch c.Path.String() {
+		case "a":
+			return c.Theirs, true
+		case "c":
+			return c.Ours, true
+		}
+		return nil, false
+	})
+	assertJSON(t, merged, `{"a": 3, "b": 2}`)
+	if len(conflicts) != 1 || conflicts[0].Path.String() != "b" {
+		t.Errorf("got %#v", conflicts)
+	}
+}
+
+func TestMerge3WithDelete(t *testing.T) {
+	base := jester.New(map[string]any{"a": 1, "b": 1, "c": 1})
+	ours := jester.New(map[string]any{"a": 2, "b": 2, "c": 2})
+	theirs := jester.New(map[string]any{"a": 3, "b": 3})
+
+	merged, conflicts := jester.Merge3With(base, ours, theirs, func(c jester.Conflict) (*jester.Data, bool) {
+		switch c.Path.String() {
+		case "a":
+			return nil, true
+		case "c":
+			return c.Theirs, true
+		}
+		return nil, false
+	})
+	assertJSON(t, merged, `{"b": 2}`)
+	if len(conflicts) != 1 || conflicts[0].Path.String() != "b" {
+		t.Errorf("got %#v", conflicts)
+	}
+}
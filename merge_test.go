@@ -0,0 +1,178 @@
+package jester_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestMergePatch(t *testing.T) {
+	// Examples from RFC 7396, Appendix A.
+	cases := []struct {
+		target, patch, outcome string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tc := range cases {
+		js, err := jester.NewJson([]byte(tc.target))
+		if err != nil {
+			t.Fatalf("err %#v", err)
+		}
+		patch, err := jester.NewJson([]byte(tc.patch))
+		if err != nil {
+			t.Fatalf("err %#v", err)
+		}
+
+		js.MergePatch(patch)
+		assertJSON(t, js, tc.outcome)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := `{"name": "guild", "roles": [{"id": "1", "name": "a"}, {"id": "2", "name": "b"}], "tags": ["x"], "owner": null}`
+	update := `{"name": "renamed", "roles": [{"id": "2", "color": 5}, {"id": "3", "name": "c"}], "tags": ["y"], "owner": "me"}`
+
+	cases := []struct {
+		opts    jester.MergeOptions
+		outcome string
+	}{
+		{
+			jester.MergeOptions{},
+			`{"name": "renamed", "roles": [{"id": "2", "color": 5}, {"id": "3", "name": "c"}], "tags": ["y"], "owner": "me"}`,
+		},
+		{
+			jester.MergeOptions{Arrays: jester.ArrayAppend},
+			`{"name": "renamed", "roles": [{"id": "1", "name": "a"}, {"id": "2", "name": "b"}, {"id": "2", "color": 5}, {"id": "3", "name": "c"}], "tags": ["x", "y"], "owner": "me"}`,
+		},
+		{
+			jester.MergeOptions{Arrays: jester.ArrayMergeIndex},
+			`{"name": "renamed", "roles": [{"id": "2", "name": "a", "color": 5}, {"id": "3", "name": "c"}], "tags": ["y"], "owner": "me"}`,
+		},
+		{
+			jester.MergeOptions{Arrays: jester.ArrayMergeKey, KeyField: "id"},
+			`{"name": "renamed", "roles": [{"id": "1", "name": "a"}, {"id": "2", "name": "b", "color": 5}, {"id": "3", "name": "c"}], "tags": ["x", "y"], "owner": "me"}`,
+		},
+		{
+			jester.MergeOptions{Conflicts: jester.ConflictKeep},
+			`{"name": "guild", "roles": [{"id": "2", "color": 5}, {"id": "3", "name": "c"}], "tags": ["y"], "owner": null}`,
+		},
+	}
+
+	for _, tc := range cases {
+		js, err := jester.NewJson([]byte(base))
+		if err != nil {
+			t.Fatalf("err %#v", err)
+		}
+		other, err := jester.NewJson([]byte(update))
+		if err != nil {
+			t.Fatalf("err %#v", err)
+		}
+
+		if err := js.Merge(other, tc.opts); err != nil {
+			t.Fatalf("err %#v", err)
+		}
+		assertJSON(t, js, tc.outcome)
+
+		// The merged document must not share containers with other.
+		other.Get("roles", 1).Set("name", "changed")
+		assertJSON(t, js, tc.outcome)
+	}
+}
+
+func TestMergeConflictError(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"a": {"b": 1, "c": 2}, "d": 1}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	other, _ := jester.NewJson([]byte(`{"a": {"b": 1.0, "e": 3}}`))
+	if err := js.Merge(other, jester.MergeOptions{Conflicts: jester.ConflictError}); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js, `{"a": {"b": 1, "c": 2, "e": 3}, "d": 1}`)
+
+	other, _ = jester.NewJson([]byte(`{"a": {"f": 1, "c": "x"}}`))
+	if err := js.Merge(other, jester.MergeOptions{Conflicts: jester.ConflictError}); !errors.Is(err, jester.ErrMergeConflict) {
+		t.Fatalf("got %#v", err)
+	}
+	assertJSON(t, js, `{"a": {"b": 1, "c": 2, "e": 3}, "d": 1}`)
+
+	// Replacing arrays is not a conflict.
+	other, _ = jester.NewJson([]byte(`{"a": {"x": [1, 2]}}`))
+	if err := js.Merge(other, jester.MergeOptions{Conflicts: jester.ConflictError}); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	other, _ = jester.NewJson([]byte(`{"a": {"x": [3]}}`))
+	if err := js.Merge(other, jester.MergeOptions{Conflicts: jester.ConflictError}); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js, `{"a": {"b": 1, "c": 2, "e": 3, "x": [3]}, "d": 1}`)
+	js.Get("a").Delete("x")
+
+	other, _ = jester.NewJson([]byte(`{"a": {"c": null}, "d": null}`))
+	if err := js.Merge(other, jester.MergeOptions{NullDeletes: true}); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js, `{"a": {"b": 1, "e": 3}}`)
+}
+
+func TestMergeNull(t *testing.T) {
+	cases := []struct {
+		opts    jester.MergeOptions
+		outcome string
+		err     error
+	}{
+		{jester.MergeOptions{}, `{"a": null, "b": {"c": null, "d": 2}}`, nil},
+		{jester.MergeOptions{Conflicts: jester.ConflictKeep}, `{"a": 1, "b": {"c": 1, "d": 2}}`, nil},
+		{jester.MergeOptions{Conflicts: jester.ConflictError}, `{"a": 1, "b": {"c": 1, "d": 2}}`, jester.ErrMergeConflict},
+		{jester.MergeOptions{Conflicts: jester.ConflictError, NullDeletes: true}, `{"b": {"d": 2}}`, nil},
+	}
+
+	for _, tc := range cases {
+		js, err := jester.NewJson([]byte(`{"a": 1, "b": {"c": 1, "d": 2}}`))
+		if err != nil {
+			t.Fatalf("err %#v", err)
+		}
+		other, err := jester.NewJson([]byte(`{"a": null, "b": {"c": null}}`))
+		if err != nil {
+			t.Fatalf("err %#v", err)
+		}
+
+		if err := js.Merge(other, tc.opts); !errors.Is(err, tc.err) {
+			t.Fatalf("%+v: err %#v", tc.opts, err)
+		}
+		assertJSON(t, js, tc.outcome)
+	}
+
+	// A null held by the receiver conflicts with a value like any other,
+	// while absent members are added.
+	js, _ := jester.NewJson([]byte(`{"x": null}`))
+	other, _ := jester.NewJson([]byte(`{"x": 3, "y": 4}`))
+	if err := js.Merge(other, jester.MergeOptions{Conflicts: jester.ConflictError}); !errors.Is(err, jester.ErrMergeConflict) {
+		t.Fatalf("got %#v", err)
+	}
+	if err := js.Merge(other, jester.MergeOptions{Conflicts: jester.ConflictKeep}); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js, `{"x": null, "y": 4}`)
+	if err := js.Merge(other, jester.MergeOptions{}); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js, `{"x": 3, "y": 4}`)
+}
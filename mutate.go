@@ -0,0 +1,265 @@
+package jester
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrNegativeIndex = errors.New("jester: negative array index")
+
+// PathError records a mutation that failed at a specific segment of a path.
+type PathError struct {
+	Op    string // the operation, e.g. "set" or "delete"
+	Path  Path   // the full path of the operation
+	Index int    // the index in Path of the segment that failed
+	Err   error  // the reason, e.g. ErrTypeMismatch or ErrIndexOutOfRange
+}
+
+func (e *PathError) Error() string {
+	reason := strings.TrimPrefix(e.Err.Error(), "jester: ")
+	if e.Index < 0 || e.Index >= len(e.Path) {
+		return fmt.Sprintf("jester: %s %s: %s", e.Op, displayPath(e.Path), reason)
+	}
+	return fmt.Sprintf("jester: %s %s: segment %d (%v): %s", e.Op, displayPath(e.Path), e.Index, e.Path[e.Index], reason)
+}
+
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// SetOptions configures SetPathE.
+type SetOptions struct {
+	// Strict refuses to convert existing values into containers. Only missing
+	// and null values are replaced, by an object for a string key or by an
+	// array for an int key. Int keys are never used as object keys.
+	Strict bool
+	// MaxGrowth limits how many elements an array may grow by to reach an
+	// index past its end; the gap is filled with nulls. Zero means no limit.
+	MaxGrowth int
+}
+
+// SetE sets the value for the specified key like Set, but returns an error
+// if the underlying data is not an object.
+func (d *Data) SetE(key string, val any) error {
+	m, err := d.Map()
+	if err != nil {
+		return &PathError{Op: "set", Path: Path{key}, Index: 0, Err: err}
+	}
+	m[key] = val
+	return nil
+}
+
+// SetPathE sets the value for the specified path like SetPath, but reports
+// why and at which segment the path could not be set. Without options it
+// follows SetPath: missing values, nulls and scalars along the path are
+// replaced by objects and int keys on objects are used as decimal keys.
+// The root itself is never replaced; unless the path is empty, it must be
+// an object or an array. On error the data is left unchanged.
+func (d *Data) SetPathE(branch []any, val any, opts ...SetOptions) error {
+	var o SetOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	path, err := opPath("set", branch)
+	if err != nil {
+		return err
+	}
+
+	if len(path) > 0 {
+		switch d.data.(type) {
+		case map[string]any, []any:
+		default:
+			return &PathError{Op: "set", Path: path, Index: 0, Err: fmt.Errorf("%w: cannot set a path on %s", ErrTypeMismatch, d.Kind())}
+		}
+	}
+
+	root, err := setPath(d.data, path, 0, val, &o)
+	if err != nil {
+		return err
+	}
+
+	d.data, d.missing = root, false
+	return nil
+}
+
+// opPath normalizes the keys of branch, reporting unsupported keys as a PathError for op.
+func opPath(op string, branch []any) (Path, error) {
+	path := make(Path, len(branch))
+	for i, key := range branch {
+		k, ok := normalizeKey(key)
+		if !ok {
+			return nil, &PathError{Op: op, Path: branch, Index: i, Err: fmt.Errorf("%w: unsupported key type %T", ErrInvalidPath, key)}
+		}
+		path[i] = k
+	}
+	return path, nil
+}
+
+// modifyPath replaces the existing value at path[depth:] with the result of fn,
+// which also receives whether the last key exists. Unlike setPath nothing is
+// created along the way. Errors returned by fn are reported at the full path.
+func modifyPath(node any, path Path, depth int, op string, fn func(v any, exists bool) (any, error)) (any, error) {
+	if depth == len(path) {
+		res, err := fn(node, true)
+		if err != nil {
+			return nil, &PathError{Op: op, Path: path, Index: len(path), Err: err}
+		}
+		return res, nil
+	}
+
+	fail := func(err error) (any, error) {
+		return nil, &PathError{Op: op, Path: path, Index: depth, Err: err}
+	}
+	last := depth == len(path)-1
+
+	switch n := node.(type) {
+	case map[string]any:
+		var k string
+		switch key := path[depth].(type) {
+		case string:
+			k = key
+		case int:
+			k = strconv.Itoa(key)
+		}
+
+		child, ok := n[k]
+		if last {
+			res, err := fn(child, ok)
+			if err != nil {
+				return nil, &PathError{Op: op, Path: path, Index: len(path), Err: err}
+			}
+			n[k] = res
+			return n, nil
+		}
+		if !ok {
+			return fail(ErrNotFound)
+		}
+
+		child, err := modifyPath(child, path, depth+1, op, fn)
+		if err != nil {
+			return nil, err
+		}
+		n[k] = child
+		return n, nil
+	case []any:
+		idx, ok := path[depth].(int)
+		switch {
+		case !ok:
+			return fail(fmt.Errorf("%w: cannot use key %q on array", ErrTypeMismatch, path[depth]))
+		case idx < 0:
+			return fail(ErrNegativeIndex)
+		case idx >= len(n):
+			return fail(ErrIndexOutOfRange)
+		}
+
+		if last {
+			res, err := fn(n[idx], true)
+			if err != nil {
+				return nil, &PathError{Op: op, Path: path, Index: len(path), Err: err}
+			}
+			n[idx] = res
+			return n, nil
+		}
+
+		child, err := modifyPath(n[idx], path, depth+1, op, fn)
+		if err != nil {
+			return nil, err
+		}
+		n[idx] = child
+		return n, nil
+	case nil:
+		return fail(ErrNotFound)
+	}
+
+	return fail(fmt.Errorf("%w: cannot index into %s", ErrTypeMismatch, New(node).Kind()))
+}
+
+// setPath returns node with the value at path[depth:] set to val. Containers
+// are written back only after the nested set succeeded.
+func setPath(node any, path Path, depth int, val any, opts *SetOptions) (any, error) {
+	if depth == len(path) {
+		return val, nil
+	}
+
+	fail := func(err error) (any, error) {
+		return nil, &PathError{Op: "set", Path: path, Index: depth, Err: err}
+	}
+
+	switch key := path[depth].(type) {
+	case string:
+		m, ok := node.(map[string]any)
+		if !ok {
+			if _, isSlice := node.([]any); isSlice || (node != nil && opts.Strict) {
+				return fail(fmt.Errorf("%w: cannot set key %q on %s", ErrTypeMismatch, key, New(node).Kind()))
+			}
+			m = make(map[string]any)
+		}
+
+		child, err := setPath(m[key], path, depth+1, val, opts)
+		if err != nil {
+			return nil, err
+		}
+		m[key] = child
+		return m, nil
+	case int:
+		if key < 0 {
+			return fail(ErrNegativeIndex)
+		}
+
+		s, ok := node.([]any)
+		if !ok && opts.Strict {
+			if node != nil {
+				return fail(fmt.Errorf("%w: cannot set index %d on %s", ErrTypeMismatch, key, New(node).Kind()))
+			}
+			s, ok = []any{}, true
+		}
+
+		if !ok {
+			// Without an array to index into, the index is used as an object key.
+			m, isMap := node.(map[string]any)
+			if !isMap {
+				m = make(map[string]any)
+			}
+			k := strconv.Itoa(key)
+			child, err := setPath(m[k], path, depth+1, val, opts)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = child
+			return m, nil
+		}
+
+		if key >= len(s) {
+			if growth := key + 1 - len(s); opts.MaxGrowth > 0 && growth > opts.MaxGrowth {
+				return fail(fmt.Errorf("%w: growing array of length %d to index %d exceeds the limit of %d", ErrIndexOutOfRange, len(s), key, opts.MaxGrowth))
+			}
+			s = append(s, make([]any, key+1-len(s))...)
+		}
+
+		child, err := setPath(s[key], path, depth+1, val, opts)
+		if err != nil {
+			return nil, err
+		}
+		s[key] = child
+		return s, nil
+	}
+
+	return fail(ErrInvalidPath)
+}
+
+// DeleteE deletes a key like Delete, but returns an error if the underlying
+// data is not an object or does not contain the key.
+func (d *Data) DeleteE(key string) error {
+	m, err := d.Map()
+	if err != nil {
+		return &PathError{Op: "delete", Path: Path{key}, Index: 0, Err: err}
+	}
+	if _, ok := m[key]; !ok {
+		return &PathError{Op: "delete", Path: Path{key}, Index: 0, Err: ErrNotFound}
+	}
+	delete(m, key)
+	return nil
+}
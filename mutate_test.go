@@ -0,0 +1,139 @@
+package jester_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestSetE(t *testing.T) {
+	js := jester.New([]any{1})
+
+	err := js.SetE("a", 1)
+	var pe *jester.PathError
+	if !errors.As(err, &pe) || !errors.Is(err, jester.ErrTypeMismatch) {
+		t.Fatalf("got %#v", err)
+	}
+
+	js = jester.NewEmpty()
+	if err := js.SetE("a", 1); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if err := js.DeleteE("a"); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if err := js.DeleteE("a"); !errors.Is(err, jester.ErrNotFound) {
+		t.Errorf("got %#v", err)
+	}
+	if err := jester.New("x").DeleteE("a"); !errors.Is(err, jester.ErrTypeMismatch) {
+		t.Errorf("got %#v", err)
+	}
+}
+
+func TestSetPathE(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"list": [1, 2], "obj": {"n": 1}, "s": "str"}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	if err := js.SetPathE([]any{"list", 4}, 5); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js.Get("list"), `[1, 2, null, null, 5]`)
+
+	if err := js.SetPathE([]any{"list", 1, "x"}, true); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js.Get("list", 1), `{"x": true}`)
+
+	if err := js.SetPathE([]any{"new", 0, "a"}, 1); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js.Get("new"), `{"0": {"a": 1}}`)
+
+	cases := []struct {
+		path  []any
+		opts  jester.SetOptions
+		index int
+		err   error
+	}{
+		{[]any{"list", -1}, jester.SetOptions{}, 1, jester.ErrNegativeIndex},
+		{[]any{"list", "key"}, jester.SetOptions{}, 1, jester.ErrTypeMismatch},
+		{[]any{"list", 10}, jester.SetOptions{MaxGrowth: 3}, 1, jester.ErrIndexOutOfRange},
+		{[]any{"s", "x"}, jester.SetOptions{Strict: true}, 1, jester.ErrTypeMismatch},
+		{[]any{"obj", 0}, jester.SetOptions{Strict: true}, 1, jester.ErrTypeMismatch},
+		{[]any{"obj", "n", "deeper"}, jester.SetOptions{Strict: true}, 2, jester.ErrTypeMismatch},
+		{[]any{"obj", 1.5}, jester.SetOptions{}, 1, jester.ErrInvalidPath},
+	}
+
+	for _, tc := range cases {
+		err := js.SetPathE(tc.path, "v", tc.opts)
+		var pe *jester.PathError
+		if !errors.As(err, &pe) {
+			t.Fatalf("%#v: got %#v", tc.path, err)
+		}
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%#v: got %v expected %v", tc.path, err, tc.err)
+		}
+		if pe.Index != tc.index {
+			t.Errorf("%#v: got index %d expected %d", tc.path, pe.Index, tc.index)
+		}
+	}
+
+	// Failed sets must not leave partial changes behind.
+	assertJSON(t, js, `{"list": [1, {"x": true}, null, null, 5], "obj": {"n": 1}, "s": "str", "new": {"0": {"a": 1}}}`)
+
+	if err := js.SetPathE([]any{"list", 7}, 8, jester.SetOptions{MaxGrowth: 3}); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if err := js.SetPathE([]any{"fresh", 1, "k"}, "v", jester.SetOptions{Strict: true}); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js.Get("fresh"), `[null, {"k": "v"}]`)
+
+	err = js.SetPathE([]any{"obj", "n", "deeper"}, 1, jester.SetOptions{Strict: true})
+	if msg := err.Error(); !strings.Contains(msg, `obj.n.deeper`) || !strings.Contains(msg, "segment 2") {
+		t.Errorf("got %q", msg)
+	}
+
+	err = js.SetPathE([]any{"s", "a.b", "c"}, 1, jester.SetOptions{Strict: true})
+	if expected := `jester: set s["a.b"].c: segment 1 (a.b): type assertion failed (type mismatch): cannot set key "a.b" on string`; err == nil || err.Error() != expected {
+		t.Errorf("got %v expected %q", err, expected)
+	}
+}
+
+func TestSetPathGrowsNestedArrays(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"a": {"list": []}}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	js.SetPath([]any{"a", "list", 1}, "x")
+	if s := js.Get("a", "list").MustStringSlice(); len(s) != 2 || s[1] != "x" {
+		t.Errorf("got %#v", s)
+	}
+}
+
+func TestSetPathRoot(t *testing.T) {
+	// The root is never converted into an object, as SetPath always did.
+	for _, js := range []*jester.Data{jester.New("x"), jester.New(nil), jester.NewEmpty().Get("missing")} {
+		js.SetPath([]any{"a"}, 1)
+		if js.Has("a") {
+			t.Errorf("got %#v", js.Interface())
+		}
+
+		err := js.SetPathE([]any{"a"}, 1)
+		if !errors.Is(err, jester.ErrTypeMismatch) {
+			t.Fatalf("err %#v", err)
+		}
+		if pe, ok := err.(*jester.PathError); !ok || pe.Index != 0 {
+			t.Errorf("err %#v", err)
+		}
+	}
+
+	js := jester.New(nil)
+	js.SetPath([]any{}, map[string]any{"a": 1})
+	assertJSON(t, js, `{"a": 1}`)
+}
@@ -0,0 +1,129 @@
+package jester
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/goccy/go-json"
+)
+
+var (
+	ErrOutOfRange = errors.New("jester: number out of range")
+	ErrNotInteger = errors.New("jester: number is not an integer")
+)
+
+// IntStrict returns the underlying data as an int like Int, but fails with
+// ErrNotInteger for fractional values and with ErrOutOfRange for values that
+// do not fit an int instead of truncating them. Integral values in any form
+// are accepted, such as json.Number("1e3") or float64(2).
+func (d *Data) IntStrict() (int, error) {
+	i, err := intValue(d.number(), strconv.IntSize)
+	return int(i), err
+}
+
+// Int32Strict returns the underlying data as an int32, checked like IntStrict.
+func (d *Data) Int32Strict() (int32, error) {
+	i, err := intValue(d.number(), 32)
+	return int32(i), err
+}
+
+// Int64Strict returns the underlying data as an int64, checked like IntStrict.
+func (d *Data) Int64Strict() (int64, error) {
+	return intValue(d.number(), 64)
+}
+
+// Uint64Strict returns the underlying data as a uint64, checked like IntStrict.
+// Negative values fail with ErrOutOfRange instead of wrapping around.
+func (d *Data) Uint64Strict() (uint64, error) {
+	return uintValue(d.number(), 64)
+}
+
+// isNumber reports whether v is of a numeric type, including non-finite floats.
+func isNumber(v any) bool {
+	switch v.(type) {
+	case float32, float64:
+		return true
+	}
+	_, ok := toRat(v)
+	return ok
+}
+
+// integerRat returns v as a rational number that is an integer, or an error
+// describing why v cannot be converted to the integer type named by typ and bits.
+func integerRat(v any, typ string, bits int) (*big.Rat, error) {
+	r, ok := toRat(v)
+	switch {
+	case !ok && isNumber(v):
+		return nil, fmt.Errorf("%w: %v", ErrNotInteger, v) // NaN or an infinity
+	case !ok:
+		return nil, fmt.Errorf("%w: cannot convert %s to %s%d", ErrTypeMismatch, New(v).Kind(), typ, bits)
+	case !r.IsInt():
+		return nil, fmt.Errorf("%w: %v", ErrNotInteger, v)
+	}
+	return r, nil
+}
+
+// intValue converts a number to a signed integer of the given bit size.
+func intValue(v any, bits int) (int64, error) {
+	r, err := integerRat(v, "int", bits)
+	if err != nil {
+		return 0, err
+	}
+	n := r.Num()
+	if !n.IsInt64() {
+		return 0, fmt.Errorf("%w: %v overflows int%d", ErrOutOfRange, v, bits)
+	}
+	i := n.Int64()
+	if bits < 64 && (i < -1<<(bits-1) || i > 1<<(bits-1)-1) {
+		return 0, fmt.Errorf("%w: %v overflows int%d", ErrOutOfRange, v, bits)
+	}
+	return i, nil
+}
+
+// uintValue converts a number to an unsigned integer of the given bit size.
+func uintValue(v any, bits int) (uint64, error) {
+	r, err := integerRat(v, "uint", bits)
+	if err != nil {
+		return 0, err
+	}
+	n := r.Num()
+	if n.Sign() < 0 {
+		return 0, fmt.Errorf("%w: %v is negative", ErrOutOfRange, v)
+	}
+	if !n.IsUint64() {
+		return 0, fmt.Errorf("%w: %v overflows uint%d", ErrOutOfRange, v, bits)
+	}
+	u := n.Uint64()
+	if bits < 64 && u > 1<<bits-1 {
+		return 0, fmt.Errorf("%w: %v overflows uint%d", ErrOutOfRange, v, bits)
+	}
+	return u, nil
+}
+
+// floatValue converts a number to a float of the given bit size, rounding to
+// the nearest representable value.
+func floatValue(v any, bits int) (float64, error) {
+	var f float64
+	switch x := v.(type) {
+	case float64:
+		f = x
+	case float32:
+		f = float64(x)
+	default:
+		r, ok := toRat(v)
+		if !ok {
+			return 0, fmt.Errorf("%w: cannot convert %s to float%d", ErrTypeMismatch, New(v).Kind(), bits)
+		}
+		f, _ = r.Float64()
+	}
+	if bits == 32 && !math.IsInf(f, 0) && !math.IsNaN(f) && math.Abs(f) > math.MaxFloat32 {
+		return 0, fmt.Errorf("%w: %v overflows float32", ErrOutOfRange, v)
+	}
+	if _, ok := v.(json.Number); ok && math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%w: %v overflows float%d", ErrOutOfRange, v, bits)
+	}
+	return f, nil
+}
@@ -0,0 +1,85 @@
+package jester_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestIntStrict(t *testing.T) {
+	for _, tc := range []struct {
+		in       any
+		expected int64
+	}{
+		{json.Number("42"), 42},
+		{json.Number("1e3"), 1000},
+		{json.Number("-2.50e1"), -25},
+		{json.Number("3.0"), 3},
+		{float64(7), 7},
+		{uint64(math.MaxInt64), math.MaxInt64},
+		{int8(-8), -8},
+	} {
+		v, err := jester.New(tc.in).Int64Strict()
+		if err != nil {
+			t.Fatalf("%#v: err %#v", tc.in, err)
+		}
+		if v != tc.expected {
+			t.Errorf("%#v: got %#v expected %#v", tc.in, v, tc.expected)
+		}
+	}
+
+	for _, tc := range []struct {
+		in  any
+		err error
+	}{
+		{json.Number("1.9"), jester.ErrNotInteger},
+		{1.9, jester.ErrNotInteger},
+		{math.NaN(), jester.ErrNotInteger},
+		{json.Number("9223372036854775808"), jester.ErrOutOfRange},
+		{uint64(math.MaxUint64), jester.ErrOutOfRange},
+		{"1", jester.ErrTypeMismatch},
+		{nil, jester.ErrTypeMismatch},
+	} {
+		if _, err := jester.New(tc.in).Int64Strict(); !errors.Is(err, tc.err) {
+			t.Errorf("%#v: err %#v", tc.in, err)
+		}
+	}
+
+	if _, err := jester.New(json.Number("2147483648")).Int32Strict(); !errors.Is(err, jester.ErrOutOfRange) {
+		t.Errorf("err %#v", err)
+	}
+	if v, err := jester.New(json.Number("-2147483648")).Int32Strict(); err != nil || v != math.MinInt32 {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+	if v, err := jester.New(json.Number("1e2")).IntStrict(); err != nil || v != 100 {
+		t.Errorf("got %#v err %#v", v, err)
+	}
+}
+
+func TestUint64Strict(t *testing.T) {
+	v, err := jester.New(json.Number("18446744073709551615")).Uint64Strict()
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if v != math.MaxUint64 {
+		t.Errorf("got %#v", v)
+	}
+
+	_, err = jester.New(-1).Uint64Strict()
+	if !errors.Is(err, jester.ErrOutOfRange) {
+		t.Fatalf("err %#v", err)
+	}
+	if expected := "jester: number out of range: -1 is negative"; err.Error() != expected {
+		t.Errorf("got %q expected %q", err.Error(), expected)
+	}
+
+	if _, err := jester.New(json.Number("18446744073709551616")).Uint64Strict(); !errors.Is(err, jester.ErrOutOfRange) {
+		t.Errorf("err %#v", err)
+	}
+	if _, err := jester.New(0.5).Uint64Strict(); !errors.Is(err, jester.ErrNotInteger) {
+		t.Errorf("err %#v", err)
+	}
+}
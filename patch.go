@@ -0,0 +1,305 @@
+package jester
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+var (
+	ErrInvalidPatch = errors.New("jester: invalid JSON patch")
+	ErrTestFailed   = errors.New("jester: JSON patch test failed")
+)
+
+// maxLCSCells bounds the table used to align array elements in Diff.
+// Larger arrays are compared index by index.
+const maxLCSCells = 1 << 20
+
+// ApplyPatch applies an RFC 6902 JSON Patch document to the data.
+// The patch is applied to a copy of the data, so either every operation
+// succeeds or the data is left untouched.
+func (d *Data) ApplyPatch(patch *Data) error {
+	ops, err := patch.Slice()
+	if err != nil {
+		return fmt.Errorf("%w: patch must be an array", ErrInvalidPatch)
+	}
+
+	doc := deepCopy(d.data)
+	for i, op := range ops {
+		doc, err = applyPatchOp(doc, New(op))
+		if err != nil {
+			return fmt.Errorf("jester: patch operation %d: %w", i, err)
+		}
+	}
+
+	d.data = doc
+	return nil
+}
+
+func applyPatchOp(doc any, op *Data) (any, error) {
+	name, err := op.Get("op").String()
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing \"op\"", ErrInvalidPatch)
+	}
+
+	path, err := patchPointer(op, "path")
+	if err != nil {
+		return nil, err
+	}
+
+	value := func() (any, error) {
+		m, _ := op.Map()
+		v, ok := m["value"]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s requires \"value\"", ErrInvalidPatch, name)
+		}
+		return deepCopy(v), nil
+	}
+
+	switch name {
+	case "add":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		return patchAdd(doc, path, v)
+	case "remove":
+		return patchRemove(doc, path)
+	case "replace":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return v, nil
+		}
+		return modifyPointer(doc, path, 0, false, func(parent any, tok string) (any, error) {
+			switch p := parent.(type) {
+			case map[string]any:
+				if _, ok := p[tok]; !ok {
+					return nil, ErrNotFound
+				}
+				p[tok] = v
+				return p, nil
+			case []any:
+				idx, err := arrayIndex(tok, len(p))
+				if err != nil {
+					return nil, err
+				}
+				if idx >= len(p) {
+					return nil, ErrIndexOutOfRange
+				}
+				p[idx] = v
+				return p, nil
+			}
+			return nil, ErrTypeMismatch
+		})
+	case "move", "copy":
+		from, err := patchPointer(op, "from")
+		if err != nil {
+			return nil, err
+		}
+		v, err := resolvePointer(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if name == "copy" {
+			return patchAdd(doc, path, deepCopy(v))
+		}
+
+		if slices.Equal(from, path) {
+			return doc, nil
+		}
+		if len(from) < len(path) && slices.Equal(from, path[:len(from)]) {
+			return nil, fmt.Errorf("%w: cannot move %q into itself", ErrInvalidPatch, FormatPointer(from...))
+		}
+		if doc, err = patchRemove(doc, from); err != nil {
+			return nil, err
+		}
+		return patchAdd(doc, path, v)
+	case "test":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		cur, err := resolvePointer(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !jsonEqual(cur, v) {
+			return nil, fmt.Errorf("%w (at %q)", ErrTestFailed, FormatPointer(path...))
+		}
+		return doc, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, name)
+}
+
+func patchPointer(op *Data, member string) ([]string, error) {
+	ptr, err := op.Get(member).String()
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing %q", ErrInvalidPatch, member)
+	}
+	return ParsePointer(ptr)
+}
+
+// patchAdd implements the "add" operation: object members are created or
+// replaced, array elements are inserted before the referenced index.
+func patchAdd(doc any, path []string, val any) (any, error) {
+	if len(path) == 0 {
+		return val, nil
+	}
+
+	return modifyPointer(doc, path, 0, false, func(parent any, tok string) (any, error) {
+		switch p := parent.(type) {
+		case map[string]any:
+			p[tok] = val
+			return p, nil
+		case []any:
+			idx, err := arrayIndex(tok, len(p))
+			if err != nil {
+				return nil, err
+			}
+			if idx > len(p) {
+				return nil, ErrIndexOutOfRange
+			}
+			return slices.Insert(p, idx, val), nil
+		}
+		return nil, ErrTypeMismatch
+	})
+}
+
+func patchRemove(doc any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, errDeleteRoot
+	}
+	return modifyPointer(doc, path, 0, false, removeToken)
+}
+
+// Diff returns an RFC 6902 JSON Patch document that transforms a into b.
+// Unchanged subtrees are skipped, objects are compared member by member and
+// array elements are aligned on their longest common subsequence, so the
+// patch only touches what actually changed.
+func Diff(a, b *Data) *Data {
+	ops := diffValues(make([]any, 0), nil, a.data, b.data)
+	return New(ops)
+}
+
+func diffValues(ops []any, path []string, a, b any) []any {
+	if jsonEqual(a, b) {
+		return ops
+	}
+
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		for _, k := range sortedKeys(x) {
+			if _, ok := y[k]; !ok {
+				ops = append(ops, patchOp("remove", appendToken(path, k), nil))
+			}
+		}
+		for _, k := range sortedKeys(y) {
+			if xv, ok := x[k]; ok {
+				ops = diffValues(ops, appendToken(path, k), xv, y[k])
+			} else {
+				ops = append(ops, patchOp("add", appendToken(path, k), y[k]))
+			}
+		}
+		return ops
+	case []any:
+		if y, ok := b.([]any); ok {
+			return diffArrays(ops, path, x, y)
+		}
+	}
+
+	return append(ops, patchOp("replace", path, b))
+}
+
+// diffArrays aligns the elements of x and y and emits removals, insertions
+// and nested diffs for elements that were changed in place.
+func diffArrays(ops []any, path []string, x, y []any) []any {
+	prefix := 0
+	for prefix < len(x) && prefix < len(y) && jsonEqual(x[prefix], y[prefix]) {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(x)-prefix && suffix < len(y)-prefix && jsonEqual(x[len(x)-1-suffix], y[len(y)-1-suffix]) {
+		suffix++
+	}
+	xs, ys := x[prefix:len(x)-suffix], y[prefix:len(y)-suffix]
+
+	pos := prefix
+	i, j := 0, 0
+	flush := func(di, dj int) {
+		// Pair removed and inserted elements up as in-place modifications.
+		for i < di && j < dj {
+			ops = diffValues(ops, appendToken(path, strconv.Itoa(pos)), xs[i], ys[j])
+			i, j, pos = i+1, j+1, pos+1
+		}
+		for ; i < di; i++ {
+			ops = append(ops, patchOp("remove", appendToken(path, strconv.Itoa(pos)), nil))
+		}
+		for ; j < dj; j++ {
+			ops = append(ops, patchOp("add", appendToken(path, strconv.Itoa(pos)), ys[j]))
+			pos++
+		}
+	}
+
+	for _, m := range lcsPairs(xs, ys) {
+		flush(m[0], m[1])
+		i, j, pos = i+1, j+1, pos+1
+	}
+	flush(len(xs), len(ys))
+
+	return ops
+}
+
+// lcsPairs returns the index pairs of a longest common subsequence of x and y.
+func lcsPairs(x, y []any) [][2]int {
+	n, m := len(x), len(y)
+	if n == 0 || m == 0 || n*m > maxLCSCells {
+		return nil
+	}
+
+	table := make([]int, (n+1)*(m+1))
+	at := func(i, j int) *int { return &table[i*(m+1)+j] }
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if jsonEqual(x[i], y[j]) {
+				*at(i, j) = *at(i+1, j+1) + 1
+			} else {
+				*at(i, j) = max(*at(i+1, j), *at(i, j+1))
+			}
+		}
+	}
+
+	var pairs [][2]int
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case jsonEqual(x[i], y[j]):
+			pairs = append(pairs, [2]int{i, j})
+			i, j = i+1, j+1
+		case *at(i+1, j) >= *at(i, j+1):
+			i++
+		default:
+			j++
+		}
+	}
+	return pairs
+}
+
+func patchOp(op string, path []string, value any) map[string]any {
+	m := map[string]any{"op": op, "path": FormatPointer(path...)}
+	if op != "remove" {
+		m["value"] = deepCopy(value)
+	}
+	return m
+}
+
+func appendToken(path []string, tok string) []string {
+	return append(path[:len(path):len(path)], tok)
+}
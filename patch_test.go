@@ -0,0 +1,147 @@
+package jester_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestApplyPatch(t *testing.T) {
+	cases := []struct {
+		doc, patch, outcome string
+	}{
+		{`{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux"}]`, `{"baz": "qux", "foo": "bar"}`},
+		{`{"foo": ["bar", "baz"]}`, `[{"op": "add", "path": "/foo/1", "value": "qux"}]`, `{"foo": ["bar", "qux", "baz"]}`},
+		{`{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/-", "value": ["abc"]}]`, `{"foo": ["bar", ["abc"]]}`},
+		{`{"baz": "qux", "foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`, `{"foo": "bar"}`},
+		{`{"foo": ["bar", "qux", "baz"]}`, `[{"op": "remove", "path": "/foo/1"}]`, `{"foo": ["bar", "baz"]}`},
+		{`{"baz": "qux", "foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "boo"}]`, `{"baz": "boo", "foo": "bar"}`},
+		{`{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`, `[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`, `{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`},
+		{`{"foo": ["all", "grass", "cows", "eat"]}`, `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`, `{"foo": ["all", "cows", "eat", "grass"]}`},
+		{`{"foo": {"bar": 1}}`, `[{"op": "copy", "from": "/foo", "path": "/baz"}, {"op": "replace", "path": "/baz/bar", "value": 2}]`, `{"foo": {"bar": 1}, "baz": {"bar": 2}}`},
+		{`{"baz": "qux", "foo": ["a", 2, "c"]}`, `[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2.0}]`, `{"baz": "qux", "foo": ["a", 2, "c"]}`},
+		{`{"foo": "bar"}`, `[{"op": "replace", "path": "", "value": [1]}]`, `[1]`},
+	}
+
+	for _, tc := range cases {
+		js, err := jester.NewJson([]byte(tc.doc))
+		if err != nil {
+			t.Fatalf("err %#v", err)
+		}
+		patch, err := jester.NewJson([]byte(tc.patch))
+		if err != nil {
+			t.Fatalf("err %#v", err)
+		}
+
+		if err := js.ApplyPatch(patch); err != nil {
+			t.Fatalf("%s: err %#v", tc.patch, err)
+		}
+		assertJSON(t, js, tc.outcome)
+	}
+}
+
+func TestApplyPatchAtomic(t *testing.T) {
+	cases := []struct {
+		patch string
+		err   error
+	}{
+		{`[{"op": "add", "path": "/a", "value": 2}, {"op": "test", "path": "/b", "value": 1}]`, jester.ErrTestFailed},
+		{`[{"op": "remove", "path": "/list/0"}, {"op": "remove", "path": "/missing"}]`, jester.ErrNotFound},
+		{`[{"op": "add", "path": "/list/5", "value": 1}]`, jester.ErrIndexOutOfRange},
+		{`[{"op": "move", "from": "/obj", "path": "/obj/child"}]`, jester.ErrInvalidPatch},
+		{`[{"op": "add", "path": "/a"}]`, jester.ErrInvalidPatch},
+		{`[{"op": "frobnicate", "path": "/a"}]`, jester.ErrInvalidPatch},
+		{`{"op": "add", "path": "/a", "value": 1}`, jester.ErrInvalidPatch},
+	}
+
+	for _, tc := range cases {
+		js, err := jester.NewJson([]byte(`{"a": 1, "b": 2, "list": [1, 2], "obj": {}}`))
+		if err != nil {
+			t.Fatalf("err %#v", err)
+		}
+		patch, err := jester.NewJson([]byte(tc.patch))
+		if err != nil {
+			t.Fatalf("err %#v", err)
+		}
+
+		if err := js.ApplyPatch(patch); !errors.Is(err, tc.err) {
+			t.Errorf("%s: got %#v expected %#v", tc.patch, err, tc.err)
+		}
+		assertJSON(t, js, `{"a": 1, "b": 2, "list": [1, 2], "obj": {}}`)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	cases := []struct {
+		a, b string
+		ops  int
+	}{
+		{`{"a": 1}`, `{"a": 1}`, 0},
+		{`{"a": 1, "b": 2}`, `{"a": 1, "c": 3}`, 2},
+		{`{"a": {"b": {"c": 1, "d": 2}}}`, `{"a": {"b": {"c": 1, "d": 3}}}`, 1},
+		{`[1, 2, 3, 4, 5]`, `[0, 1, 2, 3, 4, 5]`, 1},
+		{`[1, 2, 3, 4, 5]`, `[1, 2, 4, 5]`, 1},
+		{`[{"id": 1, "n": "a"}, {"id": 2, "n": "b"}]`, `[{"id": 1, "n": "a"}, {"id": 2, "n": "c"}, {"id": 3}]`, 2},
+		{`{"a": [1, 2]}`, `{"a": "x"}`, 1},
+		{`1`, `"x"`, 1},
+		{`[1, 2, 3]`, `[]`, 3},
+		{`[]`, `[1, 2, 3]`, 3},
+	}
+
+	for _, tc := range cases {
+		a, err := jester.NewJson([]byte(tc.a))
+		if err != nil {
+			t.Fatalf("err %#v", err)
+		}
+		b, err := jester.NewJson([]byte(tc.b))
+		if err != nil {
+			t.Fatalf("err %#v", err)
+		}
+
+		patch := jester.Diff(a, b)
+		if l := patch.Len(); l != tc.ops {
+			p, _ := json.Marshal(patch)
+			t.Errorf("%s -> %s: got %d ops expected %d: %s", tc.a, tc.b, l, tc.ops, p)
+		}
+
+		// Round-trip the patch through JSON to make sure it is a plain document.
+		raw, err := json.Marshal(patch)
+		if err != nil {
+			t.Fatalf("err %#v", err)
+		}
+		patch, err = jester.NewJson(raw)
+		if err != nil {
+			t.Fatalf("err %#v", err)
+		}
+
+		if err := a.ApplyPatch(patch); err != nil {
+			t.Fatalf("%s: err %#v", raw, err)
+		}
+		assertJSON(t, a, tc.b)
+	}
+}
+
+func assertJSON(t *testing.T, js *jester.Data, expected string) {
+	t.Helper()
+
+	want, err := jester.NewJson([]byte(expected))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	got, err := json.Marshal(js)
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	norm, err := jester.NewJson(got)
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	if !reflect.DeepEqual(norm.Interface(), want.Interface()) {
+		t.Errorf("got %s expected %s", got, expected)
+	}
+}
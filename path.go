@@ -0,0 +1,269 @@
+package jester
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidPath = errors.New("jester: invalid path")
+
+// Path is a location in a Data tree: a sequence of object keys (string) and
+// array indexes (int). A Path can be passed to Get as Get(path...).
+//
+// Paths parsed with ParsePath are already normalized, so a path that is used
+// repeatedly should be parsed once and reused rather than parsed on every call.
+type Path []any
+
+// NewPath builds a Path from keys. Integer keys of any type are converted to
+// int; keys of any other type than string or integer are rejected.
+func NewPath(keys ...any) (Path, error) {
+	p := make(Path, len(keys))
+	for i, key := range keys {
+		k, ok := normalizeKey(key)
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported key %#v of type %T", ErrInvalidPath, key, key)
+		}
+		p[i] = k
+	}
+	return p, nil
+}
+
+// ParsePath parses a path written in one of the following forms:
+//
+//	guilds.0.name             dotted keys, where decimal segments are indexes
+//	guilds[0]["name.with.dot"] bracketed indexes and quoted keys
+//	$.guilds[0]['name']       the same, with a leading "$" as in JSONPath
+//	/guilds/0/name            an RFC 6901 JSON Pointer
+//
+// The empty string and "$" refer to the root. Indexes also address object
+// members with the same decimal key, like Get does.
+func ParsePath(s string) (Path, error) {
+	if s == "" {
+		return Path{}, nil
+	}
+
+	if s[0] == '/' {
+		tokens, err := ParsePointer(s)
+		if err != nil {
+			return nil, err
+		}
+		p := make(Path, len(tokens))
+		for i, tok := range tokens {
+			p[i] = indexOrKey(tok)
+		}
+		return p, nil
+	}
+
+	p := Path{}
+	pos := 0
+	if s[0] == '$' && (len(s) == 1 || s[1] == '.' || s[1] == '[') {
+		pos = 1
+	}
+
+	for pos < len(s) {
+		switch s[pos] {
+		case '[':
+			pos++
+			if pos < len(s) && (s[pos] == '"' || s[pos] == '\'') {
+				jp := &jpParser{src: s, pos: pos}
+				key, err := jp.parseString()
+				if err != nil {
+					return nil, fmt.Errorf("%w: %q: bad quoted key", ErrInvalidPath, s)
+				}
+				p, pos = append(p, key), jp.pos
+			} else {
+				end := strings.IndexByte(s[pos:], ']')
+				if end < 0 {
+					return nil, fmt.Errorf("%w: %q: unterminated '['", ErrInvalidPath, s)
+				}
+				idx, ok := indexOrKey(s[pos : pos+end]).(int)
+				if !ok {
+					return nil, fmt.Errorf("%w: %q: bad index %q", ErrInvalidPath, s, s[pos:pos+end])
+				}
+				p, pos = append(p, idx), pos+end
+			}
+			if pos >= len(s) || s[pos] != ']' {
+				return nil, fmt.Errorf("%w: %q: expected ']'", ErrInvalidPath, s)
+			}
+			pos++
+		case '.':
+			if pos == 0 || pos == len(s)-1 {
+				return nil, fmt.Errorf("%w: %q: empty key", ErrInvalidPath, s)
+			}
+			pos++
+			if s[pos] == '.' || s[pos] == '[' {
+				return nil, fmt.Errorf("%w: %q: empty key", ErrInvalidPath, s)
+			}
+		default:
+			if pos > 0 && s[pos-1] == ']' {
+				return nil, fmt.Errorf("%w: %q: expected '.' or '[' after ']'", ErrInvalidPath, s)
+			}
+			end := strings.IndexAny(s[pos:], ".[")
+			if end < 0 {
+				end = len(s) - pos
+			}
+			p, pos = append(p, indexOrKey(s[pos:pos+end])), pos+end
+		}
+	}
+
+	return p, nil
+}
+
+// MustParsePath is like ParsePath but panics if the path cannot be parsed.
+// It simplifies initialization of package-level paths.
+func MustParsePath(s string) Path {
+	p, err := ParsePath(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String returns the path in the dotted and bracketed form accepted by ParsePath.
+// Keys that would be ambiguous in dotted form are written as quoted brackets.
+func (p Path) String() string {
+	var b strings.Builder
+	for i, seg := range p {
+		switch k := seg.(type) {
+		case int:
+			b.WriteByte('[')
+			b.WriteString(strconv.Itoa(k))
+			b.WriteByte(']')
+		case string:
+			if !plainKey(k) {
+				b.WriteByte('[')
+				writeQuoted(&b, k)
+				b.WriteByte(']')
+				continue
+			}
+			if i > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(k)
+		default:
+			fmt.Fprintf(&b, "[%v]", k)
+		}
+	}
+	return b.String()
+}
+
+// Pointer returns the path as an RFC 6901 JSON Pointer.
+func (p Path) Pointer() string {
+	tokens := make([]string, len(p))
+	for i, seg := range p {
+		switch k := seg.(type) {
+		case string:
+			tokens[i] = k
+		case int:
+			tokens[i] = strconv.Itoa(k)
+		}
+	}
+	return FormatPointer(tokens...)
+}
+
+// Parent returns the path without its last element. The parent of the root is the root.
+func (p Path) Parent() Path {
+	if len(p) == 0 {
+		return Path{}
+	}
+	return p[: len(p)-1 : len(p)-1]
+}
+
+// Append returns a new path extended by keys. Integer keys of any type are
+// converted to int. The receiver is never modified.
+func (p Path) Append(keys ...any) Path {
+	res := make(Path, len(p), len(p)+len(keys))
+	copy(res, p)
+	for _, key := range keys {
+		if k, ok := normalizeKey(key); ok {
+			key = k
+		}
+		res = append(res, key)
+	}
+	return res
+}
+
+// child returns a copy of p extended by key that does not share p's backing array.
+func (p Path) child(key any) Path {
+	return append(p[:len(p):len(p)], key)
+}
+
+// normalizeKey converts a path key to a string or an int, reporting false for
+// unsupported key types and integers that do not fit in an int.
+func normalizeKey(key any) (any, bool) {
+	switch k := key.(type) {
+	case string, int:
+		return k, true
+	case int8:
+		return int(k), true
+	case int16:
+		return int(k), true
+	case int32:
+		return int(k), true
+	case int64:
+		if k < math.MinInt || k > math.MaxInt {
+			return nil, false
+		}
+		return int(k), true
+	case uint:
+		if k > math.MaxInt {
+			return nil, false
+		}
+		return int(k), true
+	case uint8:
+		return int(k), true
+	case uint16:
+		return int(k), true
+	case uint32:
+		if uint64(k) > math.MaxInt {
+			return nil, false
+		}
+		return int(k), true
+	case uint64:
+		if k > math.MaxInt {
+			return nil, false
+		}
+		return int(k), true
+	}
+	return nil, false
+}
+
+// indexOrKey returns tok as an int if it is a canonical decimal array index,
+// and as a string key otherwise.
+func indexOrKey(tok string) any {
+	if tok == "" || tok == "-" {
+		return tok
+	}
+	if idx, err := arrayIndex(tok, 0); err == nil {
+		return idx
+	}
+	return tok
+}
+
+// plainKey reports whether key can be written in dotted form and read back as the same key.
+func plainKey(key string) bool {
+	if key == "" || key == "$" || key[0] == '/' || strings.ContainsAny(key, ".[]'\"") {
+		return false
+	}
+	_, isIndex := indexOrKey(key).(int)
+	return !isIndex
+}
+
+func writeQuoted(b *strings.Builder, s string) {
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20:
+			fmt.Fprintf(b, `\u%04x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+}
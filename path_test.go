@@ -0,0 +1,146 @@
+package jester_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestParsePath(t *testing.T) {
+	cases := []struct {
+		src  string
+		path jester.Path
+	}{
+		{"", jester.Path{}},
+		{"$", jester.Path{}},
+		{"guilds.0.name", jester.Path{"guilds", 0, "name"}},
+		{`guilds[0]["name.with.dot"]`, jester.Path{"guilds", 0, "name.with.dot"}},
+		{`$.guilds[0]['it\'s']`, jester.Path{"guilds", 0, "it's"}},
+		{`$['store']['book'][2]`, jester.Path{"store", "book", 2}},
+		{"/guilds/0/a~1b", jester.Path{"guilds", 0, "a/b"}},
+		{"/", jester.Path{""}},
+		{"a.01", jester.Path{"a", "01"}},
+		{"$set.x", jester.Path{"$set", "x"}},
+		{`["a\"b\\c"]`, jester.Path{`a"b\c`}},
+	}
+
+	for _, tc := range cases {
+		p, err := jester.ParsePath(tc.src)
+		if err != nil {
+			t.Fatalf("%q: err %#v", tc.src, err)
+		}
+		if !reflect.DeepEqual(p, tc.path) {
+			t.Errorf("%q: got %#v expected %#v", tc.src, p, tc.path)
+		}
+	}
+
+	for _, src := range []string{"a..b", ".a", "a.", "a[0", "a[x]", "a[-1]", `a["x]`, "a[0]b", "/a~2"} {
+		if _, err := jester.ParsePath(src); err == nil {
+			t.Errorf("%q: expected error", src)
+		}
+	}
+}
+
+func TestPathString(t *testing.T) {
+	cases := []struct {
+		path jester.Path
+		str  string
+		ptr  string
+	}{
+		{jester.Path{}, "", ""},
+		{jester.Path{"guilds", 0, "name"}, "guilds[0].name", "/guilds/0/name"},
+		{jester.Path{"a.b", "c"}, `["a.b"].c`, "/a.b/c"},
+		{jester.Path{"0", 0}, `["0"][0]`, "/0/0"},
+		{jester.Path{"", "x/y"}, `[""].x/y`, "//x~1y"},
+		{jester.Path{`q"\`}, `["q\"\\"]`, `/q"\`},
+	}
+
+	for _, tc := range cases {
+		if s := tc.path.String(); s != tc.str {
+			t.Errorf("got %#v expected %#v", s, tc.str)
+		}
+		if s := tc.path.Pointer(); s != tc.ptr {
+			t.Errorf("got %#v expected %#v", s, tc.ptr)
+		}
+
+		// String form must round-trip, except for keys that look like indexes
+		// which ParsePath reads back as ints.
+		p, err := jester.ParsePath(tc.str)
+		if err != nil {
+			t.Fatalf("%q: err %#v", tc.str, err)
+		}
+		if !reflect.DeepEqual(p, tc.path) {
+			t.Errorf("%q: got %#v expected %#v", tc.str, p, tc.path)
+		}
+	}
+}
+
+func TestPathAppendParent(t *testing.T) {
+	base := jester.MustParsePath("guilds[0]")
+
+	a := base.Append("name")
+	b := base.Append(int64(1), uint8(2))
+	if !reflect.DeepEqual(a, jester.Path{"guilds", 0, "name"}) {
+		t.Errorf("got %#v", a)
+	}
+	if !reflect.DeepEqual(b, jester.Path{"guilds", 0, 1, 2}) {
+		t.Errorf("got %#v", b)
+	}
+	if !reflect.DeepEqual(base, jester.Path{"guilds", 0}) {
+		t.Errorf("Append modified the receiver: %#v", base)
+	}
+
+	parent := a.Parent()
+	if !reflect.DeepEqual(parent, base) {
+		t.Errorf("got %#v", parent)
+	}
+	if c := parent.Append("id"); !reflect.DeepEqual(a, jester.Path{"guilds", 0, "name"}) || len(c) != 3 {
+		t.Errorf("appending to a parent modified the child: %#v", a)
+	}
+	if p := (jester.Path{}).Parent(); len(p) != 0 {
+		t.Errorf("got %#v", p)
+	}
+}
+
+func TestNewPath(t *testing.T) {
+	p, err := jester.NewPath("a", int64(1), uint(2), int8(3))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if !reflect.DeepEqual(p, jester.Path{"a", 1, 2, 3}) {
+		t.Errorf("got %#v", p)
+	}
+
+	if _, err := jester.NewPath("a", 1.5); !errors.Is(err, jester.ErrInvalidPath) {
+		t.Errorf("got %#v", err)
+	}
+}
+
+func TestGetIntegerKeys(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"guilds": [{"name": "a"}, {"name": "b", "name.with.dot": "c"}]}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	if s := js.Get("guilds", int64(1), "name").MustString(); s != "b" {
+		t.Errorf("got %#v", s)
+	}
+	if s := js.Get("guilds", uint(0), "name").MustString(); s != "a" {
+		t.Errorf("got %#v", s)
+	}
+	if js.Has("guilds", 1.0) {
+		t.Errorf("float keys should not resolve")
+	}
+
+	p := jester.MustParsePath(`guilds[1]["name.with.dot"]`)
+	if s := js.Get(p...).MustString(); s != "c" {
+		t.Errorf("got %#v", s)
+	}
+
+	js.SetPath([]any{"guilds", int32(0), "name"}, "z")
+	if s := js.Get("guilds", 0, "name").MustString(); s != "z" {
+		t.Errorf("got %#v", s)
+	}
+}
@@ -0,0 +1,271 @@
+package jester
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidPointer  = errors.New("jester: invalid JSON pointer")
+	ErrNotFound        = errors.New("jester: path not found")
+	ErrIndexOutOfRange = errors.New("jester: array index out of range")
+	errDeleteRoot      = errors.New("jester: cannot delete the document root")
+)
+
+// ParsePointer splits an RFC 6901 JSON Pointer into its unescaped reference tokens.
+// The empty pointer refers to the whole document and yields no tokens.
+func ParsePointer(ptr string) ([]string, error) {
+	if ptr == "" {
+		return nil, nil
+	}
+	if ptr[0] != '/' {
+		return nil, fmt.Errorf("%w: %q does not start with '/'", ErrInvalidPointer, ptr)
+	}
+
+	tokens := strings.Split(ptr[1:], "/")
+	for i, tok := range tokens {
+		if !strings.Contains(tok, "~") {
+			continue
+		}
+
+		var b strings.Builder
+		for j := 0; j < len(tok); j++ {
+			if tok[j] != '~' {
+				b.WriteByte(tok[j])
+				continue
+			}
+			if j+1 >= len(tok) || (tok[j+1] != '0' && tok[j+1] != '1') {
+				return nil, fmt.Errorf("%w: bad escape in %q", ErrInvalidPointer, ptr)
+			}
+			if tok[j+1] == '0' {
+				b.WriteByte('~')
+			} else {
+				b.WriteByte('/')
+			}
+			j++
+		}
+		tokens[i] = b.String()
+	}
+
+	return tokens, nil
+}
+
+// FormatPointer builds an RFC 6901 JSON Pointer from unescaped reference tokens.
+func FormatPointer(tokens ...string) string {
+	var b strings.Builder
+	for _, tok := range tokens {
+		b.WriteByte('/')
+		b.WriteString(escapeToken(tok))
+	}
+	return b.String()
+}
+
+var tokenEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func escapeToken(tok string) string {
+	return tokenEscaper.Replace(tok)
+}
+
+// GetPointer retrieves the value referenced by an RFC 6901 JSON Pointer.
+// Tokens are used as object keys or array indexes depending on the node they are applied to.
+func (d *Data) GetPointer(ptr string) (*Data, error) {
+	tokens, err := ParsePointer(ptr)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := resolvePointer(d.data, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.child(node), nil
+}
+
+// resolvePointer follows tokens from node and returns the referenced value.
+func resolvePointer(node any, tokens []string) (any, error) {
+	for i, tok := range tokens {
+		switch v := node.(type) {
+		case map[string]any:
+			child, ok := v[tok]
+			if !ok {
+				return nil, pointerError(ErrNotFound, tokens[:i+1])
+			}
+			node = child
+		case []any:
+			idx, err := arrayIndex(tok, len(v))
+			if err != nil {
+				return nil, pointerError(err, tokens[:i+1])
+			}
+			if idx >= len(v) {
+				return nil, pointerError(ErrIndexOutOfRange, tokens[:i+1])
+			}
+			node = v[idx]
+		default:
+			return nil, pointerError(ErrTypeMismatch, tokens[:i])
+		}
+	}
+	return node, nil
+}
+
+// SetPointer sets the value referenced by an RFC 6901 JSON Pointer.
+// Missing object members along the way are created as objects; an array index
+// equal to the array length or the "-" token appends to the array.
+func (d *Data) SetPointer(ptr string, val any) error {
+	tokens, err := ParsePointer(ptr)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		d.data = val
+		return nil
+	}
+
+	root, err := modifyPointer(d.data, tokens, 0, true, func(parent any, tok string) (any, error) {
+		switch v := parent.(type) {
+		case map[string]any:
+			v[tok] = val
+			return v, nil
+		case []any:
+			idx, err := arrayIndex(tok, len(v))
+			if err != nil {
+				return nil, err
+			}
+			if idx > len(v) {
+				return nil, ErrIndexOutOfRange
+			}
+			if idx == len(v) {
+				return append(v, val), nil
+			}
+			v[idx] = val
+			return v, nil
+		}
+		return nil, ErrTypeMismatch
+	})
+	if err != nil {
+		return err
+	}
+
+	d.data = root
+	return nil
+}
+
+// DeletePointer removes the value referenced by an RFC 6901 JSON Pointer.
+// Removing an array element shifts the following elements down by one.
+func (d *Data) DeletePointer(ptr string) error {
+	tokens, err := ParsePointer(ptr)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		return errDeleteRoot
+	}
+
+	root, err := modifyPointer(d.data, tokens, 0, false, removeToken)
+	if err != nil {
+		return err
+	}
+
+	d.data = root
+	return nil
+}
+
+// removeToken removes the member or element named by tok from parent.
+func removeToken(parent any, tok string) (any, error) {
+	switch v := parent.(type) {
+	case map[string]any:
+		if _, ok := v[tok]; !ok {
+			return nil, ErrNotFound
+		}
+		delete(v, tok)
+		return v, nil
+	case []any:
+		idx, err := arrayIndex(tok, len(v))
+		if err != nil {
+			return nil, err
+		}
+		if idx >= len(v) {
+			return nil, ErrIndexOutOfRange
+		}
+		return slices.Delete(v, idx, idx+1), nil
+	}
+	return nil, ErrTypeMismatch
+}
+
+// modifyPointer walks node along tokens and calls fn with the container holding
+// the last token. The (possibly reallocated) containers are written back on the
+// way up, so nothing is modified unless fn succeeds. When create is set,
+// missing or null object members along the way are replaced by empty objects.
+func modifyPointer(node any, tokens []string, depth int, create bool, fn func(parent any, tok string) (any, error)) (any, error) {
+	if node == nil && create {
+		node = make(map[string]any)
+	}
+
+	tok := tokens[depth]
+	if depth == len(tokens)-1 {
+		res, err := fn(node, tok)
+		if err != nil {
+			return nil, pointerError(err, tokens[:depth+1])
+		}
+		return res, nil
+	}
+
+	switch v := node.(type) {
+	case map[string]any:
+		child, ok := v[tok]
+		if !ok && !create {
+			return nil, pointerError(ErrNotFound, tokens[:depth+1])
+		}
+		child, err := modifyPointer(child, tokens, depth+1, create, fn)
+		if err != nil {
+			return nil, err
+		}
+		v[tok] = child
+		return v, nil
+	case []any:
+		idx, err := arrayIndex(tok, len(v))
+		if err != nil {
+			return nil, pointerError(err, tokens[:depth+1])
+		}
+		if idx >= len(v) {
+			return nil, pointerError(ErrIndexOutOfRange, tokens[:depth+1])
+		}
+		child, err := modifyPointer(v[idx], tokens, depth+1, create, fn)
+		if err != nil {
+			return nil, err
+		}
+		v[idx] = child
+		return v, nil
+	}
+
+	return nil, pointerError(ErrTypeMismatch, tokens[:depth])
+}
+
+// arrayIndex parses an array reference token. The "-" token refers to the
+// (nonexistent) element after the last one and resolves to n.
+func arrayIndex(tok string, n int) (int, error) {
+	if tok == "-" {
+		return n, nil
+	}
+	if tok == "" || (len(tok) > 1 && tok[0] == '0') {
+		return 0, fmt.Errorf("%w: bad array index %q", ErrInvalidPointer, tok)
+	}
+	for i := 0; i < len(tok); i++ {
+		if tok[i] < '0' || tok[i] > '9' {
+			return 0, fmt.Errorf("%w: bad array index %q", ErrInvalidPointer, tok)
+		}
+	}
+
+	idx, err := strconv.Atoi(tok)
+	if err != nil {
+		return 0, ErrIndexOutOfRange
+	}
+	return idx, nil
+}
+
+func pointerError(err error, tokens []string) error {
+	return fmt.Errorf("%w (at %q)", err, FormatPointer(tokens...))
+}
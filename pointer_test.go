@@ -0,0 +1,179 @@
+package jester_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestParsePointer(t *testing.T) {
+	cases := []struct {
+		ptr    string
+		tokens []string
+	}{
+		{"", nil},
+		{"/", []string{""}},
+		{"/foo/0", []string{"foo", "0"}},
+		{"/a~1b/m~0n", []string{"a/b", "m~n"}},
+		{"/~01", []string{"~1"}},
+	}
+
+	for _, tc := range cases {
+		tokens, err := jester.ParsePointer(tc.ptr)
+		if err != nil {
+			t.Fatalf("err %#v", err)
+		}
+		if !reflect.DeepEqual(tokens, tc.tokens) {
+			t.Errorf("got %#v expected %#v", tokens, tc.tokens)
+		}
+		if ptr := jester.FormatPointer(tokens...); ptr != tc.ptr {
+			t.Errorf("got %#v expected %#v", ptr, tc.ptr)
+		}
+	}
+
+	for _, ptr := range []string{"foo", "/a~2", "/a~"} {
+		if _, err := jester.ParsePointer(ptr); !errors.Is(err, jester.ErrInvalidPointer) {
+			t.Errorf("%q: got %#v", ptr, err)
+		}
+	}
+}
+
+func TestGetPointer(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{
+		"foo": ["bar", "baz"],
+		"": 0,
+		"a/b": 1,
+		"m~n": 8,
+		"0": {"1": "map"}
+	}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	cases := []struct {
+		ptr     string
+		outcome any
+	}{
+		{"/foo/0", "bar"},
+		{"/foo/1", "baz"},
+		{"/", 0},
+		{"/a~1b", 1},
+		{"/m~0n", 8},
+		{"/0/1", "map"},
+	}
+
+	for _, tc := range cases {
+		v, err := js.GetPointer(tc.ptr)
+		if err != nil {
+			t.Fatalf("%q: err %#v", tc.ptr, err)
+		}
+		switch want := tc.outcome.(type) {
+		case string:
+			if s := v.MustString(); s != want {
+				t.Errorf("%q: got %#v expected %#v", tc.ptr, s, want)
+			}
+		case int:
+			if i := v.MustInt(-1); i != want {
+				t.Errorf("%q: got %#v expected %#v", tc.ptr, i, want)
+			}
+		}
+	}
+
+	if v, err := js.GetPointer(""); err != nil || v.Len() != 5 {
+		t.Errorf("got %#v, %#v", v, err)
+	}
+
+	errCases := []struct {
+		ptr string
+		err error
+	}{
+		{"/missing", jester.ErrNotFound},
+		{"/foo/2", jester.ErrIndexOutOfRange},
+		{"/foo/-", jester.ErrIndexOutOfRange},
+		{"/foo/01", jester.ErrInvalidPointer},
+		{"/foo/0/x", jester.ErrTypeMismatch},
+	}
+
+	for _, tc := range errCases {
+		if _, err := js.GetPointer(tc.ptr); !errors.Is(err, tc.err) {
+			t.Errorf("%q: got %#v expected %#v", tc.ptr, err, tc.err)
+		}
+	}
+}
+
+func TestSetPointer(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"foo": ["bar"]}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	if err := js.SetPointer("/foo/0", "baz"); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if err := js.SetPointer("/foo/-", "qux"); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if err := js.SetPointer("/foo/2", "quux"); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if s := js.Get("foo").MustStringSlice(); !reflect.DeepEqual(s, []string{"baz", "qux", "quux"}) {
+		t.Errorf("got %#v", s)
+	}
+
+	if err := js.SetPointer("/a~1b/c", 1); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if i := js.Get("a/b", "c").MustInt(); i != 1 {
+		t.Errorf("got %#v", i)
+	}
+
+	if err := js.SetPointer("/foo/5", "x"); !errors.Is(err, jester.ErrIndexOutOfRange) {
+		t.Errorf("got %#v", err)
+	}
+	if err := js.SetPointer("/foo/0/x", "x"); !errors.Is(err, jester.ErrTypeMismatch) {
+		t.Errorf("got %#v", err)
+	}
+	if s := js.Get("foo").MustStringSlice(); len(s) != 3 {
+		t.Errorf("failed set modified the document: %#v", s)
+	}
+
+	if err := js.SetPointer("", "root"); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if s := js.MustString(); s != "root" {
+		t.Errorf("got %#v", s)
+	}
+}
+
+func TestDeletePointer(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"foo": ["a", "b", "c"], "bar": {"baz": 1}}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	if err := js.DeletePointer("/foo/1"); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if s := js.Get("foo").MustStringSlice(); !reflect.DeepEqual(s, []string{"a", "c"}) {
+		t.Errorf("got %#v", s)
+	}
+
+	if err := js.DeletePointer("/bar/baz"); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if l := js.Get("bar").Len(); l != 0 {
+		t.Errorf("got %#v", l)
+	}
+
+	if err := js.DeletePointer("/bar/baz"); !errors.Is(err, jester.ErrNotFound) {
+		t.Errorf("got %#v", err)
+	}
+	if err := js.DeletePointer("/foo/-"); !errors.Is(err, jester.ErrIndexOutOfRange) {
+		t.Errorf("got %#v", err)
+	}
+	if err := js.DeletePointer(""); err == nil {
+		t.Errorf("expected error deleting the root")
+	}
+}
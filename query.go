@@ -0,0 +1,1101 @@
+package jester
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/goccy/go-json"
+)
+
+var ErrInvalidQuery = errors.New("jester: invalid JSONPath query")
+
+// Match is a single node selected by a JSONPath query.
+type Match struct {
+	// Path is the normalized path of the node, e.g. $['guilds'][0]['id'].
+	Path  string
+	Value *Data
+}
+
+// Query evaluates an RFC 9535 JSONPath expression and returns the selected nodes.
+// Object members are visited in sorted key order so results are deterministic.
+func (d *Data) Query(expr string) ([]*Data, error) {
+	nodes, err := d.query(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*Data, len(nodes))
+	for i, n := range nodes {
+		res[i] = d.child(n.val)
+	}
+	return res, nil
+}
+
+// QueryMatches is like Query but also reports the normalized path of every node.
+func (d *Data) QueryMatches(expr string) ([]Match, error) {
+	nodes, err := d.query(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Match, len(nodes))
+	for i, n := range nodes {
+		res[i] = Match{Path: normalizedPath(n.path), Value: d.child(n.val)}
+	}
+	return res, nil
+}
+
+func (d *Data) query(expr string) ([]jpNode, error) {
+	p := &jpParser{src: expr}
+	q, err := p.parseRootQuery()
+	if err != nil {
+		return nil, err
+	}
+	return q.eval(d.data, d.data), nil
+}
+
+// normalizedPath formats a path as an RFC 9535 normalized path.
+func normalizedPath(path Path) string {
+	var b strings.Builder
+	b.WriteByte('$')
+	for _, seg := range path {
+		switch k := seg.(type) {
+		case int:
+			b.WriteByte('[')
+			b.WriteString(strconv.Itoa(k))
+			b.WriteByte(']')
+		case string:
+			b.WriteString("['")
+			for _, r := range k {
+				switch r {
+				case '\b':
+					b.WriteString(`\b`)
+				case '\f':
+					b.WriteString(`\f`)
+				case '\n':
+					b.WriteString(`\n`)
+				case '\r':
+					b.WriteString(`\r`)
+				case '\t':
+					b.WriteString(`\t`)
+				case '\'':
+					b.WriteString(`\'`)
+				case '\\':
+					b.WriteString(`\\`)
+				default:
+					if r < 0x20 {
+						fmt.Fprintf(&b, `\u%04x`, r)
+					} else {
+						b.WriteRune(r)
+					}
+				}
+			}
+			b.WriteString("']")
+		}
+	}
+	return b.String()
+}
+
+// jpNode is a value selected during query evaluation together with its location.
+type jpNode struct {
+	path Path
+	val  any
+}
+
+func (n jpNode) child(key any, val any) jpNode {
+	return jpNode{path: n.path.child(key), val: val}
+}
+
+type jpQuery struct {
+	relative bool
+	segments []jpSegment
+}
+
+func (q *jpQuery) eval(root, cur any) []jpNode {
+	start := root
+	if q.relative {
+		start = cur
+	}
+
+	nodes := []jpNode{{val: start}}
+	for i := range q.segments {
+		nodes = q.segments[i].apply(nodes, root)
+		if len(nodes) == 0 {
+			break
+		}
+	}
+	return nodes
+}
+
+// singular reports whether the query can select at most one node.
+func (q *jpQuery) singular() bool {
+	for _, seg := range q.segments {
+		if seg.descendant || len(seg.selectors) != 1 {
+			return false
+		}
+		if k := seg.selectors[0].kind; k != selName && k != selIndex {
+			return false
+		}
+	}
+	return true
+}
+
+type jpSegment struct {
+	descendant bool
+	selectors  []jpSelector
+}
+
+func (s *jpSegment) apply(nodes []jpNode, root any) []jpNode {
+	var out []jpNode
+	for _, n := range nodes {
+		if s.descendant {
+			descend(n, func(m jpNode) {
+				for i := range s.selectors {
+					out = s.selectors[i].apply(out, m, root)
+				}
+			})
+			continue
+		}
+		for i := range s.selectors {
+			out = s.selectors[i].apply(out, n, root)
+		}
+	}
+	return out
+}
+
+// descend calls fn for n and all of its descendants in document order.
+func descend(n jpNode, fn func(jpNode)) {
+	fn(n)
+	switch v := n.val.(type) {
+	case map[string]any:
+		for _, k := range sortedKeys(v) {
+			descend(n.child(k, v[k]), fn)
+		}
+	case []any:
+		for i, e := range v {
+			descend(n.child(i, e), fn)
+		}
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+type selectorKind int
+
+const (
+	selName selectorKind = iota
+	selWildcard
+	selIndex
+	selSlice
+	selFilter
+)
+
+type jpSelector struct {
+	kind             selectorKind
+	name             string
+	index            int
+	start, end, step int
+	hasStart, hasEnd bool
+	filter           jpFilter
+}
+
+func (s *jpSelector) apply(out []jpNode, n jpNode, root any) []jpNode {
+	switch s.kind {
+	case selName:
+		if m, ok := n.val.(map[string]any); ok {
+			if v, ok := m[s.name]; ok {
+				out = append(out, n.child(s.name, v))
+			}
+		}
+	case selWildcard:
+		switch v := n.val.(type) {
+		case map[string]any:
+			for _, k := range sortedKeys(v) {
+				out = append(out, n.child(k, v[k]))
+			}
+		case []any:
+			for i, e := range v {
+				out = append(out, n.child(i, e))
+			}
+		}
+	case selIndex:
+		if a, ok := n.val.([]any); ok {
+			i := s.index
+			if i < 0 {
+				i += len(a)
+			}
+			if i >= 0 && i < len(a) {
+				out = append(out, n.child(i, a[i]))
+			}
+		}
+	case selSlice:
+		if a, ok := n.val.([]any); ok {
+			for _, i := range s.sliceIndexes(len(a)) {
+				out = append(out, n.child(i, a[i]))
+			}
+		}
+	case selFilter:
+		switch v := n.val.(type) {
+		case map[string]any:
+			for _, k := range sortedKeys(v) {
+				if s.filter.test(root, v[k]) {
+					out = append(out, n.child(k, v[k]))
+				}
+			}
+		case []any:
+			for i, e := range v {
+				if s.filter.test(root, e) {
+					out = append(out, n.child(i, e))
+				}
+			}
+		}
+	}
+	return out
+}
+
+// sliceIndexes returns the indexes selected by a slice selector on an array of length n.
+func (s *jpSelector) sliceIndexes(n int) []int {
+	step := s.step
+	if step == 0 {
+		return nil
+	}
+
+	normalize := func(i int) int {
+		if i >= 0 {
+			return i
+		}
+		return n + i
+	}
+
+	start, end := 0, n
+	if step < 0 {
+		start, end = n-1, -n-1
+	}
+	if s.hasStart {
+		start = s.start
+	}
+	if s.hasEnd {
+		end = s.end
+	}
+	start, end = normalize(start), normalize(end)
+
+	var idx []int
+	if step > 0 {
+		lower := min(max(start, 0), n)
+		upper := min(max(end, 0), n)
+		for i := lower; i < upper; i += step {
+			idx = append(idx, i)
+		}
+	} else {
+		upper := min(max(start, -1), n-1)
+		lower := min(max(end, -1), n-1)
+		for i := upper; lower < i; i += step {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// jpFilter is a logical expression evaluated against the current node.
+type jpFilter interface {
+	test(root, cur any) bool
+}
+
+type jpOr []jpFilter
+
+func (e jpOr) test(root, cur any) bool {
+	for _, f := range e {
+		if f.test(root, cur) {
+			return true
+		}
+	}
+	return false
+}
+
+type jpAnd []jpFilter
+
+func (e jpAnd) test(root, cur any) bool {
+	for _, f := range e {
+		if !f.test(root, cur) {
+			return false
+		}
+	}
+	return true
+}
+
+type jpNot struct {
+	f jpFilter
+}
+
+func (e jpNot) test(root, cur any) bool {
+	return !e.f.test(root, cur)
+}
+
+type jpExists struct {
+	q *jpQuery
+}
+
+func (e jpExists) test(root, cur any) bool {
+	return len(e.q.eval(root, cur)) > 0
+}
+
+type jpFuncTest struct {
+	f *jpFunc
+}
+
+func (e jpFuncTest) test(root, cur any) bool {
+	return e.f.logical(root, cur)
+}
+
+type jpCompare struct {
+	op          string
+	left, right jpOperand
+}
+
+func (e jpCompare) test(root, cur any) bool {
+	a, aok := e.left.value(root, cur)
+	b, bok := e.right.value(root, cur)
+
+	switch e.op {
+	case "==":
+		return jpEqual(a, aok, b, bok)
+	case "!=":
+		return !jpEqual(a, aok, b, bok)
+	case "<":
+		return jpLess(a, aok, b, bok)
+	case ">":
+		return jpLess(b, bok, a, aok)
+	case "<=":
+		return jpLess(a, aok, b, bok) || jpEqual(a, aok, b, bok)
+	case ">=":
+		return jpLess(b, bok, a, aok) || jpEqual(a, aok, b, bok)
+	}
+	return false
+}
+
+func jpEqual(a any, aok bool, b any, bok bool) bool {
+	if !aok || !bok {
+		return !aok && !bok
+	}
+	return jsonEqual(a, b)
+}
+
+func jpLess(a any, aok bool, b any, bok bool) bool {
+	if !aok || !bok {
+		return false
+	}
+	if sa, ok := a.(string); ok {
+		sb, ok := b.(string)
+		return ok && sa < sb
+	}
+	ra, ok := toRat(a)
+	if !ok {
+		return false
+	}
+	rb, ok := toRat(b)
+	return ok && ra.Cmp(rb) < 0
+}
+
+// jpOperand is a comparable: a literal, a singular query or a function
+// returning a value. The boolean result is false for the special result Nothing.
+type jpOperand struct {
+	lit   any
+	isLit bool
+	query *jpQuery
+	fn    *jpFunc
+}
+
+func (o *jpOperand) value(root, cur any) (any, bool) {
+	switch {
+	case o.isLit:
+		return o.lit, true
+	case o.query != nil:
+		nodes := o.query.eval(root, cur)
+		if len(nodes) != 1 {
+			return nil, false
+		}
+		return nodes[0].val, true
+	case o.fn != nil:
+		return o.fn.value(root, cur)
+	}
+	return nil, false
+}
+
+type jpType int
+
+const (
+	typeValue jpType = iota
+	typeLogical
+	typeNodes
+)
+
+type jpFuncDef struct {
+	params []jpType
+	result jpType
+}
+
+var jpFunctions = map[string]jpFuncDef{
+	"length": {params: []jpType{typeValue}, result: typeValue},
+	"count":  {params: []jpType{typeNodes}, result: typeValue},
+	"value":  {params: []jpType{typeNodes}, result: typeValue},
+	"match":  {params: []jpType{typeValue, typeValue}, result: typeLogical},
+	"search": {params: []jpType{typeValue, typeValue}, result: typeLogical},
+}
+
+type jpFunc struct {
+	name string
+	args []jpOperand
+	re   *regexp.Regexp // precompiled pattern when the second argument is a literal
+}
+
+func (f *jpFunc) nodes(arg *jpOperand, root, cur any) []jpNode {
+	if arg.query == nil {
+		return nil
+	}
+	return arg.query.eval(root, cur)
+}
+
+func (f *jpFunc) value(root, cur any) (any, bool) {
+	switch f.name {
+	case "length":
+		v, ok := f.args[0].value(root, cur)
+		if !ok {
+			return nil, false
+		}
+		switch x := v.(type) {
+		case string:
+			return utf8.RuneCountInString(x), true
+		case []any:
+			return len(x), true
+		case map[string]any:
+			return len(x), true
+		}
+		return nil, false
+	case "count":
+		return len(f.nodes(&f.args[0], root, cur)), true
+	case "value":
+		nodes := f.nodes(&f.args[0], root, cur)
+		if len(nodes) != 1 {
+			return nil, false
+		}
+		return nodes[0].val, true
+	}
+	return nil, false
+}
+
+func (f *jpFunc) logical(root, cur any) bool {
+	v, ok := f.args[0].value(root, cur)
+	if !ok {
+		return false
+	}
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+
+	re := f.re
+	if re == nil {
+		pv, ok := f.args[1].value(root, cur)
+		if !ok {
+			return false
+		}
+		pattern, ok := pv.(string)
+		if !ok {
+			return false
+		}
+		if re = f.compile(pattern); re == nil {
+			return false
+		}
+	}
+
+	return re.MatchString(s)
+}
+
+func (f *jpFunc) compile(pattern string) *regexp.Regexp {
+	if f.name == "match" {
+		pattern = `^(?:` + pattern + `)$`
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil
+	}
+	return re
+}
+
+// jpParser is a recursive descent parser for RFC 9535 JSONPath expressions.
+type jpParser struct {
+	src string
+	pos int
+}
+
+func (p *jpParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrInvalidQuery, fmt.Sprintf(format, args...), p.pos)
+}
+
+func (p *jpParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *jpParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *jpParser) consume(s string) bool {
+	if strings.HasPrefix(p.src[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *jpParser) skipBlank() {
+	for !p.eof() {
+		switch p.src[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *jpParser) parseRootQuery() (*jpQuery, error) {
+	if !p.consume("$") {
+		return nil, p.errorf("query must start with '$'")
+	}
+	q, err := p.parseSegments(false)
+	if err != nil {
+		return nil, err
+	}
+	if !p.eof() {
+		return nil, p.errorf("unexpected %q", p.src[p.pos:])
+	}
+	return q, nil
+}
+
+func (p *jpParser) parseSegments(relative bool) (*jpQuery, error) {
+	q := &jpQuery{relative: relative}
+	for {
+		save := p.pos
+		p.skipBlank()
+
+		var seg jpSegment
+		var err error
+		switch {
+		case p.consume(".."):
+			seg.descendant = true
+			switch {
+			case p.consume("*"):
+				seg.selectors = []jpSelector{{kind: selWildcard}}
+			case p.peek() == '[':
+				seg.selectors, err = p.parseBracket()
+			default:
+				var name string
+				name, err = p.parseMemberName()
+				seg.selectors = []jpSelector{{kind: selName, name: name}}
+			}
+		case p.consume("."):
+			if p.consume("*") {
+				seg.selectors = []jpSelector{{kind: selWildcard}}
+				break
+			}
+			var name string
+			name, err = p.parseMemberName()
+			seg.selectors = []jpSelector{{kind: selName, name: name}}
+		case p.peek() == '[':
+			seg.selectors, err = p.parseBracket()
+		default:
+			p.pos = save
+			return q, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		q.segments = append(q.segments, seg)
+	}
+}
+
+func (p *jpParser) parseMemberName() (string, error) {
+	start := p.pos
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+		first := p.pos == start
+		if !(r == '_' || r >= 0x80 || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (!first && r >= '0' && r <= '9')) {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == start {
+		return "", p.errorf("expected member name")
+	}
+	return p.src[start:p.pos], nil
+}
+
+func (p *jpParser) parseBracket() ([]jpSelector, error) {
+	p.pos++ // '['
+
+	var sels []jpSelector
+	for {
+		p.skipBlank()
+		sel, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+
+		p.skipBlank()
+		if p.consume("]") {
+			return sels, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected ',' or ']'")
+		}
+	}
+}
+
+func (p *jpParser) parseSelector() (jpSelector, error) {
+	switch c := p.peek(); {
+	case c == '\'' || c == '"':
+		name, err := p.parseString()
+		return jpSelector{kind: selName, name: name}, err
+	case c == '*':
+		p.pos++
+		return jpSelector{kind: selWildcard}, nil
+	case c == '?':
+		p.pos++
+		p.skipBlank()
+		f, err := p.parseOr()
+		return jpSelector{kind: selFilter, filter: f}, err
+	}
+
+	sel := jpSelector{kind: selIndex, step: 1}
+	if c := p.peek(); c == '-' || (c >= '0' && c <= '9') {
+		i, err := p.parseInt()
+		if err != nil {
+			return sel, err
+		}
+		sel.index, sel.start, sel.hasStart = i, i, true
+		p.skipBlank()
+	}
+	if !p.consume(":") {
+		if !sel.hasStart {
+			return sel, p.errorf("expected selector")
+		}
+		return sel, nil
+	}
+
+	sel.kind = selSlice
+	p.skipBlank()
+	if c := p.peek(); c == '-' || (c >= '0' && c <= '9') {
+		i, err := p.parseInt()
+		if err != nil {
+			return sel, err
+		}
+		sel.end, sel.hasEnd = i, true
+		p.skipBlank()
+	}
+	if p.consume(":") {
+		p.skipBlank()
+		if c := p.peek(); c == '-' || (c >= '0' && c <= '9') {
+			i, err := p.parseInt()
+			if err != nil {
+				return sel, err
+			}
+			sel.step = i
+		}
+	}
+	return sel, nil
+}
+
+// maxSafeInt is the largest integer that is exactly representable in I-JSON.
+const maxSafeInt = 1<<53 - 1
+
+func (p *jpParser) parseInt() (int, error) {
+	start := p.pos
+	p.consume("-")
+	digits := p.pos
+	for !p.eof() && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+
+	s := p.src[start:p.pos]
+	if p.pos == digits || (p.src[digits] == '0' && (p.pos-digits > 1 || digits > start)) {
+		return 0, p.errorf("bad integer %q", s)
+	}
+
+	i, err := strconv.Atoi(s)
+	if err != nil || i > maxSafeInt || i < -maxSafeInt {
+		return 0, p.errorf("integer %q out of range", s)
+	}
+	return i, nil
+}
+
+func (p *jpParser) parseString() (string, error) {
+	quote := p.src[p.pos]
+	p.pos++
+
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated string")
+		}
+
+		c := p.src[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return b.String(), nil
+		case c < 0x20:
+			return "", p.errorf("control character in string")
+		case c != '\\':
+			b.WriteByte(c)
+			p.pos++
+			continue
+		}
+
+		p.pos++
+		if p.eof() {
+			return "", p.errorf("unterminated string")
+		}
+		esc := p.src[p.pos]
+		p.pos++
+		switch esc {
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '/', '\\':
+			b.WriteByte(esc)
+		case '\'', '"':
+			if esc != quote {
+				return "", p.errorf("invalid escape \\%c", esc)
+			}
+			b.WriteByte(esc)
+		case 'u':
+			r, err := p.parseHex4()
+			if err != nil {
+				return "", err
+			}
+			if utf16.IsSurrogate(r) {
+				if !p.consume(`\u`) {
+					return "", p.errorf("unpaired surrogate")
+				}
+				r2, err := p.parseHex4()
+				if err != nil {
+					return "", err
+				}
+				if r = utf16.DecodeRune(r, r2); r == utf8.RuneError {
+					return "", p.errorf("invalid surrogate pair")
+				}
+			}
+			b.WriteRune(r)
+		default:
+			return "", p.errorf("invalid escape \\%c", esc)
+		}
+	}
+}
+
+func (p *jpParser) parseHex4() (rune, error) {
+	if p.pos+4 > len(p.src) {
+		return 0, p.errorf("bad unicode escape")
+	}
+	v, err := strconv.ParseUint(p.src[p.pos:p.pos+4], 16, 32)
+	if err != nil {
+		return 0, p.errorf("bad unicode escape")
+	}
+	p.pos += 4
+	return rune(v), nil
+}
+
+func (p *jpParser) parseOr() (jpFilter, error) {
+	var or jpOr
+	for {
+		and, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, and)
+
+		p.skipBlank()
+		if !p.consume("||") {
+			break
+		}
+		p.skipBlank()
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+func (p *jpParser) parseAnd() (jpFilter, error) {
+	var and jpAnd
+	for {
+		f, err := p.parseBasic()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, f)
+
+		p.skipBlank()
+		if !p.consume("&&") {
+			break
+		}
+		p.skipBlank()
+	}
+	if len(and) == 1 {
+		return and[0], nil
+	}
+	return and, nil
+}
+
+func (p *jpParser) parseBasic() (jpFilter, error) {
+	neg := false
+	if p.peek() == '!' && !strings.HasPrefix(p.src[p.pos:], "!=") {
+		p.pos++
+		p.skipBlank()
+		neg = true
+	}
+
+	if p.consume("(") {
+		p.skipBlank()
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipBlank()
+		if !p.consume(")") {
+			return nil, p.errorf("expected ')'")
+		}
+		if neg {
+			return jpNot{f}, nil
+		}
+		return f, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	save := p.pos
+	p.skipBlank()
+	op := p.parseCompareOp()
+	if op == "" {
+		p.pos = save
+
+		var f jpFilter
+		switch {
+		case left.query != nil:
+			f = jpExists{left.query}
+		case left.fn != nil && jpFunctions[left.fn.name].result == typeLogical:
+			f = jpFuncTest{left.fn}
+		default:
+			return nil, p.errorf("expected a test or comparison")
+		}
+		if neg {
+			return jpNot{f}, nil
+		}
+		return f, nil
+	}
+	if neg {
+		return nil, p.errorf("'!' cannot negate a comparison without parentheses")
+	}
+
+	p.skipBlank()
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range []*jpOperand{&left, &right} {
+		if err := p.checkComparable(o); err != nil {
+			return nil, err
+		}
+	}
+
+	return jpCompare{op: op, left: left, right: right}, nil
+}
+
+func (p *jpParser) parseCompareOp() string {
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(op) {
+			return op
+		}
+	}
+	return ""
+}
+
+func (p *jpParser) checkComparable(o *jpOperand) error {
+	switch {
+	case o.query != nil && !o.query.singular():
+		return p.errorf("comparisons require singular queries")
+	case o.fn != nil && jpFunctions[o.fn.name].result != typeValue:
+		return p.errorf("function %s() does not return a value", o.fn.name)
+	}
+	return nil
+}
+
+func (p *jpParser) parseOperand() (jpOperand, error) {
+	switch c := p.peek(); {
+	case c == '@' || c == '$':
+		p.pos++
+		q, err := p.parseSegments(c == '@')
+		return jpOperand{query: q}, err
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		return jpOperand{lit: s, isLit: true}, err
+	case c == '-' || (c >= '0' && c <= '9'):
+		n, err := p.parseNumber()
+		return jpOperand{lit: n, isLit: true}, err
+	}
+
+	for _, lit := range jpLiterals {
+		rest := p.src[p.pos:]
+		if strings.HasPrefix(rest, lit.text) && !strings.HasPrefix(rest[len(lit.text):], "(") {
+			p.pos += len(lit.text)
+			return jpOperand{lit: lit.val, isLit: true}, nil
+		}
+	}
+
+	fn, err := p.parseFunc()
+	return jpOperand{fn: fn}, err
+}
+
+var jpLiterals = []struct {
+	text string
+	val  any
+}{
+	{"true", true},
+	{"false", false},
+	{"null", nil},
+}
+
+func (p *jpParser) parseNumber() (json.Number, error) {
+	start := p.pos
+	p.consume("-")
+
+	digits := p.pos
+	for !p.eof() && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+	if p.pos == digits || (p.src[digits] == '0' && p.pos-digits > 1) {
+		return "", p.errorf("bad number")
+	}
+
+	if p.consume(".") {
+		frac := p.pos
+		for !p.eof() && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+			p.pos++
+		}
+		if p.pos == frac {
+			return "", p.errorf("bad number")
+		}
+	}
+
+	if c := p.peek(); c == 'e' || c == 'E' {
+		p.pos++
+		if c := p.peek(); c == '+' || c == '-' {
+			p.pos++
+		}
+		exp := p.pos
+		for !p.eof() && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+			p.pos++
+		}
+		if p.pos == exp {
+			return "", p.errorf("bad number")
+		}
+	}
+
+	return json.Number(p.src[start:p.pos]), nil
+}
+
+func (p *jpParser) parseFunc() (*jpFunc, error) {
+	start := p.pos
+	for !p.eof() {
+		c := p.src[p.pos]
+		if !((c >= 'a' && c <= 'z') || c == '_' || (p.pos > start && c >= '0' && c <= '9')) {
+			break
+		}
+		p.pos++
+	}
+	name := p.src[start:p.pos]
+	if name == "" {
+		return nil, p.errorf("expected expression")
+	}
+
+	def, ok := jpFunctions[name]
+	if !ok {
+		return nil, p.errorf("unknown function %q", name)
+	}
+	if !p.consume("(") {
+		return nil, p.errorf("expected '(' after %s", name)
+	}
+
+	fn := &jpFunc{name: name}
+	for i := range def.params {
+		p.skipBlank()
+		if i > 0 {
+			if !p.consume(",") {
+				return nil, p.errorf("%s() expects %d arguments", name, len(def.params))
+			}
+			p.skipBlank()
+		}
+
+		arg, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		switch def.params[i] {
+		case typeValue:
+			if err := p.checkComparable(&arg); err != nil {
+				return nil, err
+			}
+		case typeNodes:
+			if arg.query == nil {
+				return nil, p.errorf("%s() expects a query argument", name)
+			}
+		}
+		fn.args = append(fn.args, arg)
+	}
+
+	p.skipBlank()
+	if !p.consume(")") {
+		return nil, p.errorf("%s() expects %d arguments", name, len(def.params))
+	}
+
+	if def.result == typeLogical && fn.args[1].isLit {
+		pattern, ok := fn.args[1].lit.(string)
+		if !ok {
+			return nil, p.errorf("%s() expects a string pattern", name)
+		}
+		if fn.re = fn.compile(pattern); fn.re == nil {
+			return nil, p.errorf("invalid regular expression %q", pattern)
+		}
+	}
+
+	return fn, nil
+}
@@ -0,0 +1,127 @@
+package jester_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lb-selfbot/go-jester"
+)
+
+const storeJSON = `{
+	"store": {
+		"book": [
+			{"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95},
+			{"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+			{"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+			{"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}
+		],
+		"bicycle": {"color": "red", "price": 399}
+	}
+}`
+
+func TestQuery(t *testing.T) {
+	js, err := jester.NewJson([]byte(storeJSON))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	cases := []struct {
+		expr  string
+		paths []string
+	}{
+		{"$.store.book[*].author", []string{
+			"$['store']['book'][0]['author']",
+			"$['store']['book'][1]['author']",
+			"$['store']['book'][2]['author']",
+			"$['store']['book'][3]['author']",
+		}},
+		{"$..author", []string{
+			"$['store']['book'][0]['author']",
+			"$['store']['book'][1]['author']",
+			"$['store']['book'][2]['author']",
+			"$['store']['book'][3]['author']",
+		}},
+		{"$.store.*", []string{"$['store']['bicycle']", "$['store']['book']"}},
+		{"$..book[2]", []string{"$['store']['book'][2]"}},
+		{"$..book[-1]", []string{"$['store']['book'][3]"}},
+		{"$..book[0,1]", []string{"$['store']['book'][0]", "$['store']['book'][1]"}},
+		{"$..book[:2]", []string{"$['store']['book'][0]", "$['store']['book'][1]"}},
+		{"$..book[::-2]", []string{"$['store']['book'][3]", "$['store']['book'][1]"}},
+		{"$..book[1:5:2]", []string{"$['store']['book'][1]", "$['store']['book'][3]"}},
+		{"$..book[?@.isbn]", []string{"$['store']['book'][2]", "$['store']['book'][3]"}},
+		{"$..book[?@.price<10]", []string{"$['store']['book'][0]", "$['store']['book'][2]"}},
+		{"$..book[?@.category == 'fiction' && @.price > 20]", []string{"$['store']['book'][3]"}},
+		{"$..book[?!(@.category == 'fiction')]", []string{"$['store']['book'][0]"}},
+		{"$..book[?match(@.author, 'H.*')]", []string{"$['store']['book'][2]"}},
+		{"$..book[?search(@.title, 'Dick')]", []string{"$['store']['book'][2]"}},
+		{"$..book[?length(@.title) > 21]", []string{"$['store']['book'][0]"}},
+		{"$.store[?count(@.*) == 2]", []string{"$['store']['bicycle']"}},
+		{"$..[?@.price == $.store.bicycle.price]", []string{"$['store']['bicycle']"}},
+		{`$["store"]['bicycle']["color"]`, []string{"$['store']['bicycle']['color']"}},
+		{"$.missing", nil},
+	}
+
+	for _, tc := range cases {
+		matches, err := js.QueryMatches(tc.expr)
+		if err != nil {
+			t.Fatalf("%s: err %#v", tc.expr, err)
+		}
+
+		var paths []string
+		for _, m := range matches {
+			paths = append(paths, m.Path)
+		}
+		if !reflect.DeepEqual(paths, tc.paths) {
+			t.Errorf("%s: got %#v expected %#v", tc.expr, paths, tc.paths)
+		}
+	}
+
+	prices, err := js.Query("$..price")
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if len(prices) != 5 {
+		t.Errorf("got %d prices", len(prices))
+	}
+	if f := prices[0].MustFloat64(); f != 399 {
+		t.Errorf("got %#v", f)
+	}
+}
+
+func TestQueryNormalizedPath(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"a'b": {"c\\d": 1}}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	matches, err := js.QueryMatches("$..*")
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if len(matches) != 2 || matches[1].Path != `$['a\'b']['c\\d']` {
+		t.Errorf("got %#v", matches)
+	}
+}
+
+func TestQueryInvalid(t *testing.T) {
+	js := jester.NewEmpty()
+
+	for _, expr := range []string{
+		"",
+		"store",
+		"$.",
+		"$[",
+		"$[01]",
+		"$['a'",
+		"$[?@.a == @..b]",
+		"$[?length(@.*) > 1]",
+		"$[?unknown(@)]",
+		"$[?1]",
+		"$.a ",
+	} {
+		if _, err := js.Query(expr); !errors.Is(err, jester.ErrInvalidQuery) {
+			t.Errorf("%q: got %#v", expr, err)
+		}
+	}
+}
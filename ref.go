@@ -0,0 +1,109 @@
+package jester
+
+// Ref is a live reference to a location in a Data tree. Unlike the values
+// returned by Get, which wrap whatever was stored at the time of the call,
+// a Ref remembers its parent and key and resolves them against the original
+// document on every call, so writes through a Ref always update that document,
+// including replacing scalars and growing or shrinking arrays.
+type Ref struct {
+	root   *Data
+	parent *Ref
+	key    any
+}
+
+// At returns a live reference to the location at the specified path, which
+// does not need to exist yet.
+func (d *Data) At(keys ...any) *Ref {
+	return (&Ref{root: d}).At(keys...)
+}
+
+// At returns a reference to the location at the specified path below r.
+func (r *Ref) At(keys ...any) *Ref {
+	ref := r
+	for _, key := range keys {
+		if k, ok := normalizeKey(key); ok {
+			key = k
+		}
+		ref = &Ref{root: r.root, parent: ref, key: key}
+	}
+	return ref
+}
+
+// Parent returns the reference to the container of r. The root reference is its own parent.
+func (r *Ref) Parent() *Ref {
+	if r.parent == nil {
+		return r
+	}
+	return r.parent
+}
+
+// Key returns the object key or array index of r within its parent, or nil for the root.
+func (r *Ref) Key() any {
+	return r.key
+}
+
+// Path returns the path of r from the root of the document.
+func (r *Ref) Path() Path {
+	n := 0
+	for ref := r; ref.parent != nil; ref = ref.parent {
+		n++
+	}
+
+	path := make(Path, n)
+	for ref := r; ref.parent != nil; ref = ref.parent {
+		n--
+		path[n] = ref.key
+	}
+	return path
+}
+
+// Data returns the current value at the location of r.
+func (r *Ref) Data() *Data {
+	return r.root.Get(r.Path()...)
+}
+
+// Get retrieves the current value at the specified path below r.
+func (r *Ref) Get(keys ...any) *Data {
+	return r.Data().Get(keys...)
+}
+
+// Exists reports whether a value currently exists at the location of r.
+func (r *Ref) Exists() bool {
+	return r.root.Has(r.Path()...)
+}
+
+// Replace replaces the value at the location of r, creating it if needed like SetPathE.
+func (r *Ref) Replace(val any, opts ...SetOptions) error {
+	return r.root.SetPathE(r.Path(), val, opts...)
+}
+
+// Set sets the value for key in the object at the location of r.
+func (r *Ref) Set(key string, val any, opts ...SetOptions) error {
+	return r.root.SetPathE(r.Path().child(key), val, opts...)
+}
+
+// SetPath sets the value for the specified path below r.
+func (r *Ref) SetPath(branch []any, val any, opts ...SetOptions) error {
+	return r.root.SetPathE(r.Path().Append(branch...), val, opts...)
+}
+
+// Delete removes the value at the location of r from its parent and reports
+// whether anything was removed.
+func (r *Ref) Delete() bool {
+	return r.root.DeletePath(r.Path()...)
+}
+
+// Append appends vals to the array at the location of r, creating it if missing.
+func (r *Ref) Append(vals ...any) error {
+	return r.root.Append(r.Path(), vals...)
+}
+
+// Insert inserts val into the array at the location of r before index.
+func (r *Ref) Insert(index int, val any) error {
+	return r.root.Insert(r.Path(), index, val)
+}
+
+// RemoveAt removes the element at index from the array at the location of r.
+func (r *Ref) RemoveAt(index int) error {
+	return r.root.RemoveAt(r.Path(), index)
+}
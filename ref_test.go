@@ -0,0 +1,75 @@
+package jester_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestRef(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"guild": {"name": "g", "members": [], "count": 1}}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	guild := js.At("guild")
+	members := guild.At("members")
+
+	if err := members.Append("a", "b"); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if err := guild.At("count").Replace(2); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if err := guild.Set("name", "renamed"); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if err := members.Insert(0, "z"); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if err := members.RemoveAt(1); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if err := guild.SetPath([]any{"roles", "admin"}, true); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	assertJSON(t, js, `{"guild": {"name": "renamed", "members": ["z", "b"], "count": 2, "roles": {"admin": true}}}`)
+
+	if s := members.Get(0).MustString(); s != "z" {
+		t.Errorf("got %#v", s)
+	}
+	if l := members.Data().Len(); l != 2 {
+		t.Errorf("got %#v", l)
+	}
+
+	elem := members.At(1)
+	if !reflect.DeepEqual(elem.Path(), jester.Path{"guild", "members", 1}) {
+		t.Errorf("got %#v", elem.Path())
+	}
+	if elem.Parent() != members || elem.Key() != 1 {
+		t.Errorf("unexpected parent or key")
+	}
+	if !elem.Delete() || elem.Exists() {
+		t.Errorf("expected element to be deleted")
+	}
+
+	// A reference to a scalar can replace it in place.
+	count := js.At("guild", "count")
+	if err := count.Replace("many"); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	if s := js.Get("guild", "count").MustString(); s != "many" {
+		t.Errorf("got %#v", s)
+	}
+
+	root := js.At()
+	if root.Parent() != root || len(root.Path()) != 0 {
+		t.Errorf("unexpected root reference")
+	}
+	if err := root.Replace([]any{1}); err != nil {
+		t.Fatalf("err %#v", err)
+	}
+	assertJSON(t, js, `[1]`)
+}
@@ -0,0 +1,65 @@
+package jester
+
+import "iter"
+
+// WalkAction tells Walk how to continue after visiting a node.
+type WalkAction int
+
+const (
+	// WalkContinue descends into the node's children.
+	WalkContinue WalkAction = iota
+	// WalkSkip skips the node's children and continues with its next sibling.
+	WalkSkip
+	// WalkStop ends the walk.
+	WalkStop
+)
+
+// Walk traverses the data depth-first, calling fn for every node starting with
+// the root, whose path is empty. Object members are visited in sorted key order.
+// Each path passed to fn is a fresh slice and may be retained. A missing
+// value has no nodes, so fn is not called.
+func (d *Data) Walk(fn func(path Path, node *Data) WalkAction) {
+	if d.missing {
+		return
+	}
+	d.walk(Path{}, d.data, fn)
+}
+
+// walk visits v and its descendants and reports whether the walk was stopped.
+func (d *Data) walk(path Path, v any, fn func(Path, *Data) WalkAction) bool {
+	switch fn(path, d.child(v)) {
+	case WalkStop:
+		return true
+	case WalkSkip:
+		return false
+	}
+
+	switch x := v.(type) {
+	case map[string]any:
+		for _, k := range sortedKeys(x) {
+			if d.walk(path.child(k), x[k], fn) {
+				return true
+			}
+		}
+	case []any:
+		for i, e := range x {
+			if d.walk(path.child(i), e, fn) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// All returns an iterator over every node of the data and its path, in the
+// same order as Walk.
+func (d *Data) All() iter.Seq2[Path, *Data] {
+	return func(yield func(Path, *Data) bool) {
+		d.Walk(func(path Path, node *Data) WalkAction {
+			if !yield(path, node) {
+				return WalkStop
+			}
+			return WalkContinue
+		})
+	}
+}
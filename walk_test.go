@@ -0,0 +1,108 @@
+package jester_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lb-selfbot/go-jester"
+)
+
+func TestWalk(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"b": [1, {"c": 2}], "a": {"skip": {"x": 1}}, "d": 3}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	var paths []string
+	js.Walk(func(path jester.Path, node *jester.Data) jester.WalkAction {
+		paths = append(paths, path.Pointer())
+		if len(path) > 0 && path[len(path)-1] == "skip" {
+			return jester.WalkSkip
+		}
+		return jester.WalkContinue
+	})
+
+	expected := []string{"", "/a", "/a/skip", "/b", "/b/0", "/b/1", "/b/1/c", "/d"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("got %#v expected %#v", paths, expected)
+	}
+
+	paths = paths[:0]
+	js.Walk(func(path jester.Path, node *jester.Data) jester.WalkAction {
+		paths = append(paths, path.Pointer())
+		if node.MustInt() == 1 {
+			return jester.WalkStop
+		}
+		return jester.WalkContinue
+	})
+
+	expected = []string{"", "/a", "/a/skip", "/a/skip/x"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("got %#v expected %#v", paths, expected)
+	}
+}
+
+func TestAll(t *testing.T) {
+	js, err := jester.NewJson([]byte(`{"guilds": [{"id": "1", "members": [{"id": "2"}]}], "id": "3"}`))
+	if err != nil {
+		t.Fatalf("err %#v", err)
+	}
+
+	var saved []jester.Path
+	var ids []string
+	for path, node := range js.All() {
+		if len(path) > 0 && path[len(path)-1] == "id" {
+			saved = append(saved, path)
+			ids = append(ids, node.MustString())
+		}
+	}
+
+	if !reflect.DeepEqual(ids, []string{"1", "2", "3"}) {
+		t.Errorf("got %#v", ids)
+	}
+
+	expected := []jester.Path{
+		{"guilds", 0, "id"},
+		{"guilds", 0, "members", 0, "id"},
+		{"id"},
+	}
+	if !reflect.DeepEqual(saved, expected) {
+		t.Errorf("got %#v expected %#v", saved, expected)
+	}
+
+	for _, p := range saved {
+		if !js.Has(p...) {
+			t.Errorf("path %#v does not resolve", p)
+		}
+	}
+
+	count := 0
+	for range js.All() {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("got %#v", count)
+	}
+}
+
+func TestWalkMissing(t *testing.T) {
+	js := jester.NewEmpty()
+
+	for path := range js.Get("nope").All() {
+		t.Errorf("got node at %s", path)
+	}
+
+	var nodes int
+	for _, node := range jester.New(nil).All() {
+		if !node.IsNull() {
+			t.Errorf("got %#v", node.Interface())
+		}
+		nodes++
+	}
+	if nodes != 1 {
+		t.Errorf("got %d nodes", nodes)
+	}
+}